@@ -0,0 +1,144 @@
+package ast
+
+import "sort"
+
+// Analysis summarises what a function touches, without running it.
+type Analysis struct {
+	// ReferencedCollections lists the collections named by foreign record
+	// parameters or called by name, e.g. Account(id).
+	ReferencedCollections []string
+	// ReadsThisFields and WritesThisFields list the top-level fields of
+	// this that are read and assigned. Compound assignments such as
+	// this.balance -= 1 count as both.
+	ReadsThisFields  []string
+	WritesThisFields []string
+}
+
+// Analyze statically inspects fn, resolving collection references
+// against the collections declared in p.
+func Analyze(p *Program, fn *Function) Analysis {
+	a := analyzer{
+		collections: map[string]bool{},
+		referenced:  map[string]bool{},
+		reads:       map[string]bool{},
+		writes:      map[string]bool{},
+	}
+	for _, c := range p.Collections() {
+		a.collections[c.Name] = true
+	}
+
+	for _, param := range fn.Parameters {
+		if param.Type.IsForeignRecord() {
+			a.referenced[param.Type.ForeignRecord().Collection] = true
+		}
+	}
+
+	a.walk(fn.Statements)
+
+	return Analysis{
+		ReferencedCollections: sortedKeys(a.referenced),
+		ReadsThisFields:       sortedKeys(a.reads),
+		WritesThisFields:      sortedKeys(a.writes),
+	}
+}
+
+type analyzer struct {
+	collections map[string]bool
+	referenced  map[string]bool
+	reads       map[string]bool
+	writes      map[string]bool
+}
+
+// walk visits JSON-decoded statements and expressions. Expressions are
+// single-key objects such as {"Dot": [expr, "field"]}.
+func (a *analyzer) walk(v any) {
+	switch v := v.(type) {
+	case []any:
+		for _, item := range v {
+			a.walk(item)
+		}
+	case map[string]any:
+		for kind, content := range v {
+			operands, _ := content.([]any)
+			switch {
+			case kind == "Assign" && len(operands) == 2:
+				a.assign(operands[0], false)
+				a.walk(operands[1])
+			case (kind == "AssignAdd" || kind == "AssignSub") && len(operands) == 2:
+				a.assign(operands[0], true)
+				a.walk(operands[1])
+			case kind == "Dot" && len(operands) == 2:
+				if field, ok := thisField(operands); ok {
+					a.reads[field] = true
+				} else {
+					a.walk(operands[0])
+				}
+			case kind == "Call" && len(operands) == 2:
+				if callee, ok := ident(operands[0]); ok && a.collections[callee] {
+					a.referenced[callee] = true
+				}
+				a.walk(operands)
+			default:
+				a.walk(content)
+			}
+		}
+	}
+}
+
+// assign records the field of this that an assignment to target mutates.
+// Assigning to a nested path such as this.a.b or this.a[i] writes a.
+func (a *analyzer) assign(target any, read bool) {
+	expr, ok := target.(map[string]any)
+	if !ok {
+		return
+	}
+
+	if operands, ok := expr["Dot"].([]any); ok && len(operands) == 2 {
+		if field, ok := thisField(operands); ok {
+			a.writes[field] = true
+			if read {
+				a.reads[field] = true
+			}
+			return
+		}
+		a.assign(operands[0], read)
+		return
+	}
+
+	if operands, ok := expr["Index"].([]any); ok && len(operands) == 2 {
+		a.assign(operands[0], read)
+		a.walk(operands[1])
+		return
+	}
+
+	a.walk(target)
+}
+
+func thisField(dot []any) (string, bool) {
+	if name, ok := ident(dot[0]); !ok || name != "this" {
+		return "", false
+	}
+
+	field, ok := dot[1].(string)
+	return field, ok
+}
+
+func ident(expr any) (string, bool) {
+	m, ok := expr.(map[string]any)
+	if !ok {
+		return "", false
+	}
+
+	name, ok := m["Ident"].(string)
+	return name, ok
+}
+
+func sortedKeys(m map[string]bool) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	return keys
+}
@@ -0,0 +1,43 @@
+package ast
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+// withdrawFunction is the AST of
+//
+//	function withdraw(amount: number) {
+//	    let account = Account(this.id);
+//	    this.balance -= amount;
+//	    this.memo = this.name;
+//	}
+const withdrawFunction = `{
+	"name": "withdraw",
+	"parameters": [{"name": "amount", "type_": {"tag": "Number"}, "required": true}],
+	"return_type": null,
+	"statements": [
+		{"Let": {"identifier": "account", "expression": {"Call": [{"Ident": "Account"}, [{"Dot": [{"Ident": "this"}, "id"]}]]}}},
+		{"Expression": {"AssignSub": [{"Dot": [{"Ident": "this"}, "balance"]}, {"Ident": "amount"}]}},
+		{"Expression": {"Assign": [{"Dot": [{"Ident": "this"}, "memo"]}, {"Dot": [{"Ident": "this"}, "name"]}]}}
+	],
+	"statements_code": ""
+}`
+
+func TestAnalyze(t *testing.T) {
+	var fn Function
+	if err := json.Unmarshal([]byte(withdrawFunction), &fn); err != nil {
+		t.Fatal(err)
+	}
+
+	got := Analyze(mustProgram(t, programSource), &fn)
+	want := Analysis{
+		ReferencedCollections: []string{"Account"},
+		ReadsThisFields:       []string{"balance", "id", "name"},
+		WritesThisFields:      []string{"balance", "memo"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %+v, want %+v", got, want)
+	}
+}
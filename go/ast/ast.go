@@ -7,8 +7,8 @@ type Program struct {
 }
 
 type RootNode struct {
-	Collection *Collection
-	Function   *Function
+	Collection *Collection `json:"Collection,omitempty"`
+	Function   *Function   `json:"Function,omitempty"`
 }
 
 type Collection struct {
@@ -23,9 +23,11 @@ type CollectionItem struct {
 }
 
 type Field struct {
-	Name     string `json:"name"`
-	Type     Type   `json:"type_"`
-	Required bool   `json:"required"`
+	Name       string           `json:"name"`
+	Type       Type             `json:"type_"`
+	Required   bool             `json:"required"`
+	Default    json.RawMessage  `json:"default,omitempty"`
+	Decorators []FieldDecorator `json:"decorators,omitempty"`
 }
 
 type Type struct {
@@ -67,6 +69,55 @@ func (t *Type) Object() ([]Field, error) {
 	return fields, nil
 }
 
+// TypesEqual reports whether a and b describe the same type. Object fields
+// are compared by name, so their declaration order does not matter.
+func TypesEqual(a, b Type) bool {
+	if a.Tag != b.Tag {
+		return false
+	}
+
+	switch {
+	case a.IsArray():
+		ae, err := a.Array()
+		if err != nil {
+			return false
+		}
+		be, err := b.Array()
+		if err != nil {
+			return false
+		}
+		return TypesEqual(*ae, *be)
+	case a.IsMap():
+		ak, av, err := a.Map()
+		if err != nil {
+			return false
+		}
+		bk, bv, err := b.Map()
+		if err != nil {
+			return false
+		}
+		return TypesEqual(*ak, *bk) && TypesEqual(*av, *bv)
+	case a.IsObject():
+		af, err := a.Object()
+		if err != nil {
+			return false
+		}
+		bf, err := b.Object()
+		if err != nil || len(af) != len(bf) {
+			return false
+		}
+		for _, field := range af {
+			other := FindField(bf, field.Name)
+			if other == nil || other.Required != field.Required || !TypesEqual(field.Type, other.Type) {
+				return false
+			}
+		}
+		return true
+	default:
+		return true
+	}
+}
+
 type FieldDecorator struct {
 	Name      string      `json:"name"`
 	Arguments []Primitive `json:"arguments"`
@@ -128,9 +179,10 @@ type ForeignRecord struct {
 }
 
 type Parameter struct {
-	Name     string       `json:"name"`
-	Type     FunctionType `json:"type_"`
-	Required bool         `json:"required"`
+	Name     string          `json:"name"`
+	Type     FunctionType    `json:"type_"`
+	Required bool            `json:"required"`
+	Default  json.RawMessage `json:"default,omitempty"`
 }
 
 type Index struct {
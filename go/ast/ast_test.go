@@ -0,0 +1,70 @@
+package ast
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func mustType(t *testing.T, src string) Type {
+	t.Helper()
+
+	var typ Type
+	if err := json.Unmarshal([]byte(src), &typ); err != nil {
+		t.Fatal(err)
+	}
+
+	return typ
+}
+
+func TestTypesEqual(t *testing.T) {
+	cases := []struct {
+		name  string
+		a, b  string
+		equal bool
+	}{
+		{
+			name:  "string is not string[]",
+			a:     `{"tag": "String"}`,
+			b:     `{"tag": "Array", "content": {"tag": "String"}}`,
+			equal: false,
+		},
+		{
+			name:  "map equals itself regardless of formatting",
+			a:     `{"tag":"Map","content":[{"tag":"String"},{"tag":"Number"}]}`,
+			b:     `{"content": [ {"tag": "String"} ,  {"tag": "Number"} ], "tag": "Map"}`,
+			equal: true,
+		},
+		{
+			name:  "maps with different value types",
+			a:     `{"tag": "Map", "content": [{"tag": "String"}, {"tag": "Number"}]}`,
+			b:     `{"tag": "Map", "content": [{"tag": "String"}, {"tag": "String"}]}`,
+			equal: false,
+		},
+		{
+			name: "object field order does not matter",
+			a: `{"tag": "Object", "content": [
+				{"name": "a", "type_": {"tag": "String"}, "required": true},
+				{"name": "b", "type_": {"tag": "Number"}, "required": false}
+			]}`,
+			b: `{"tag": "Object", "content": [
+				{"name": "b", "type_": {"tag": "Number"}, "required": false},
+				{"name": "a", "type_": {"tag": "String"}, "required": true}
+			]}`,
+			equal: true,
+		},
+		{
+			name:  "object fields differing in required",
+			a:     `{"tag": "Object", "content": [{"name": "a", "type_": {"tag": "String"}, "required": true}]}`,
+			b:     `{"tag": "Object", "content": [{"name": "a", "type_": {"tag": "String"}, "required": false}]}`,
+			equal: false,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := TypesEqual(mustType(t, tc.a), mustType(t, tc.b)); got != tc.equal {
+				t.Errorf("TypesEqual = %v, want %v", got, tc.equal)
+			}
+		})
+	}
+}
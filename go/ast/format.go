@@ -0,0 +1,235 @@
+package ast
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+const indent = "  "
+
+// Format renders c as Polylang source. Function bodies are emitted verbatim
+// from StatementsCode, so formatting the result of parsing Format's output
+// yields the same source again.
+func Format(c *Collection) (string, error) {
+	var b strings.Builder
+	fmt.Fprintf(&b, "collection %s {\n", c.Name)
+
+	for i, item := range c.Items {
+		switch {
+		case item.Field != nil:
+			if err := formatField(&b, indent, item.Field); err != nil {
+				return "", err
+			}
+		case item.Index != nil:
+			b.WriteString(indent)
+			b.WriteString(formatIndex(item.Index))
+			b.WriteString(";\n")
+		case item.Function != nil:
+			if i > 0 {
+				b.WriteString("\n")
+			}
+			if err := formatFunction(&b, indent, item.Function); err != nil {
+				return "", err
+			}
+		}
+	}
+
+	b.WriteString("}\n")
+	return b.String(), nil
+}
+
+func formatField(b *strings.Builder, prefix string, field *Field) error {
+	t, err := formatType(prefix, &field.Type)
+	if err != nil {
+		return err
+	}
+
+	for _, decorator := range field.Decorators {
+		d, err := formatDecorator(&decorator)
+		if err != nil {
+			return fmt.Errorf("field %q: %w", field.Name, err)
+		}
+		t += " " + d
+	}
+
+	b.WriteString(prefix)
+	b.WriteString(field.Name)
+	switch {
+	case field.Default != nil:
+		value, err := formatDefault(field.Default)
+		if err != nil {
+			return fmt.Errorf("field %q: %w", field.Name, err)
+		}
+		fmt.Fprintf(b, ": %s = %s;\n", t, value)
+		return nil
+	case !field.Required:
+		b.WriteString("?")
+	}
+	fmt.Fprintf(b, ": %s;\n", t)
+	return nil
+}
+
+func formatDecorator(d *FieldDecorator) (string, error) {
+	args := make([]string, len(d.Arguments))
+	for i, arg := range d.Arguments {
+		switch {
+		case arg.Number != nil:
+			args[i] = strconv.FormatFloat(*arg.Number, 'f', -1, 64)
+		case arg.String != nil, arg.Regex != nil:
+			s := arg.String
+			if s == nil {
+				s = arg.Regex
+			}
+			if strings.Contains(*s, "'") {
+				return "", fmt.Errorf("@%s argument %q cannot be written as a string literal", d.Name, *s)
+			}
+			args[i] = "'" + *s + "'"
+		default:
+			return "", fmt.Errorf("@%s has an empty argument", d.Name)
+		}
+	}
+
+	return fmt.Sprintf("@%s(%s)", d.Name, strings.Join(args, ", ")), nil
+}
+
+func formatType(prefix string, t *Type) (string, error) {
+	switch {
+	case t.IsString(), t.IsNumber(), t.IsBoolean():
+		return strings.ToLower(t.Tag), nil
+	case t.IsArray():
+		elem, err := t.Array()
+		if err != nil {
+			return "", err
+		}
+		elemType, err := formatType(prefix, elem)
+		if err != nil {
+			return "", err
+		}
+		return elemType + "[]", nil
+	case t.IsMap():
+		kt, vt, err := t.Map()
+		if err != nil {
+			return "", err
+		}
+		keyType, err := formatType(prefix, kt)
+		if err != nil {
+			return "", err
+		}
+		valueType, err := formatType(prefix, vt)
+		if err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("map<%s, %s>", keyType, valueType), nil
+	case t.IsObject():
+		fields, err := t.Object()
+		if err != nil {
+			return "", err
+		}
+		if len(fields) == 0 {
+			return "{}", nil
+		}
+
+		var b strings.Builder
+		b.WriteString("{\n")
+		for i := range fields {
+			if err := formatField(&b, prefix+indent, &fields[i]); err != nil {
+				return "", err
+			}
+		}
+		b.WriteString(prefix)
+		b.WriteString("}")
+		return b.String(), nil
+	default:
+		return "", fmt.Errorf("unknown type %q", t.Tag)
+	}
+}
+
+func formatParameterType(ft *FunctionType) (string, error) {
+	switch {
+	case ft.IsRecord():
+		return "record", nil
+	case ft.IsForeignRecord():
+		return ft.ForeignRecord().Collection, nil
+	default:
+		return formatType("", &Type{Tag: ft.Tag, Content: ft.Content})
+	}
+}
+
+func formatIndex(index *Index) string {
+	fields := make([]string, len(index.Fields))
+	for i, field := range index.Fields {
+		path := strings.Join(field.Path, ".")
+		if field.Order == Desc {
+			fields[i] = fmt.Sprintf("[%s, desc]", path)
+		} else {
+			fields[i] = path
+		}
+	}
+
+	return fmt.Sprintf("@index(%s)", strings.Join(fields, ", "))
+}
+
+func formatFunction(b *strings.Builder, prefix string, fn *Function) error {
+	params := make([]string, len(fn.Parameters))
+	for i, param := range fn.Parameters {
+		t, err := formatParameterType(&param.Type)
+		if err != nil {
+			return err
+		}
+
+		switch {
+		case param.Default != nil:
+			value, err := formatDefault(param.Default)
+			if err != nil {
+				return fmt.Errorf("parameter %q: %w", param.Name, err)
+			}
+			params[i] = fmt.Sprintf("%s: %s = %s", param.Name, t, value)
+		case !param.Required:
+			params[i] = fmt.Sprintf("%s?: %s", param.Name, t)
+		default:
+			params[i] = fmt.Sprintf("%s: %s", param.Name, t)
+		}
+	}
+
+	fmt.Fprintf(b, "%sfunction %s(%s)", prefix, fn.Name, strings.Join(params, ", "))
+	if fn.ReturnType != nil {
+		t, err := formatType(prefix, fn.ReturnType)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(b, ": %s", t)
+	}
+	fmt.Fprintf(b, " {%s}\n", fn.StatementsCode)
+
+	return nil
+}
+
+// formatDefault renders a field or parameter default as a Polylang literal. Strings
+// are single-quoted, as the lexer has no double-quoted strings or escapes,
+// and numbers are written without exponents, which the lexer also lacks.
+func formatDefault(raw json.RawMessage) (string, error) {
+	var value any
+	if err := decodeJSON(string(raw), &value); err != nil {
+		return "", err
+	}
+
+	switch v := value.(type) {
+	case string:
+		if strings.Contains(v, "'") {
+			return "", fmt.Errorf("default %q cannot be written as a string literal", v)
+		}
+		return "'" + v + "'", nil
+	case json.Number:
+		f, err := v.Float64()
+		if err != nil {
+			return "", err
+		}
+		return strconv.FormatFloat(f, 'f', -1, 64), nil
+	case bool:
+		return strconv.FormatBool(v), nil
+	default:
+		return "", fmt.Errorf("unsupported default %s", raw)
+	}
+}
@@ -0,0 +1,59 @@
+package ast
+
+import "testing"
+
+func TestFormat(t *testing.T) {
+	c := readCollection(t, "testdata/user.json")
+
+	got, err := Format(c)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if want := readFixture(t, "testdata/user.polylang"); got != want {
+		t.Errorf("got:\n%s\nwant:\n%s", got, want)
+	}
+}
+
+func TestFormatFieldDefaults(t *testing.T) {
+	c := mustCollection(t, `{"name": "Account", "items": [
+		{"Field": {"name": "balance", "type_": {"tag": "Number"}, "required": false, "default": -1.0}},
+		{"Field": {"name": "currency", "type_": {"tag": "String"}, "required": false, "default": "USD"}},
+		{"Field": {"name": "frozen", "type_": {"tag": "Boolean"}, "required": false, "default": false}}
+	]}`)
+
+	got, err := Format(c)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := "collection Account {\n  balance: number = -1;\n  currency: string = 'USD';\n  frozen: boolean = false;\n}\n"
+	if got != want {
+		t.Errorf("got:\n%s\nwant:\n%s", got, want)
+	}
+}
+
+func TestFormatFieldDecorators(t *testing.T) {
+	c := mustCollection(t, `{"name": "User", "items": [
+		{"Field": {"name": "name", "type_": {"tag": "String"}, "required": true, "decorators": [
+			{"name": "regex", "arguments": [{"Regex": "^[a-z]+$"}]}
+		]}},
+		{"Field": {"name": "nick", "type_": {"tag": "String"}, "required": false, "default": "x", "decorators": [
+			{"name": "regex", "arguments": [{"Regex": "^[a-z]+$"}]}
+		]}},
+		{"Field": {"name": "age", "type_": {"tag": "Number"}, "required": true, "decorators": [
+			{"name": "min", "arguments": [{"Number": -1}]},
+			{"name": "max", "arguments": [{"Number": 150}]}
+		]}}
+	]}`)
+
+	got, err := Format(c)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := "collection User {\n  name: string @regex('^[a-z]+$');\n  nick: string @regex('^[a-z]+$') = 'x';\n  age: number @min(-1) @max(150);\n}\n"
+	if got != want {
+		t.Errorf("got:\n%s\nwant:\n%s", got, want)
+	}
+}
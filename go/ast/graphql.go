@@ -0,0 +1,150 @@
+package ast
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ToGraphQLSDL generates a GraphQL schema describing records of c, plus an
+// input type for every function that takes parameters. Types map as follows:
+//
+//   - string, number and boolean become String, Float and Boolean
+//   - arrays become lists of non-null elements
+//   - required fields and parameters are non-null
+//   - object fields become separate types named after the collection and the
+//     field path, e.g. field address of User becomes UserAddress, or
+//     UserAddressInput inside an input type
+//   - maps, and objects with no fields, have no GraphQL equivalent and
+//     become the custom scalar JSON
+//   - record and foreign record parameters become ID, the id of the record
+//     passed; resolving the relation is left to the server
+func ToGraphQLSDL(c *Collection) (string, error) {
+	g := graphQLGenerator{}
+	if err := g.writeType("type", c.Name, c.Name, c.Fields()); err != nil {
+		return "", err
+	}
+
+	for _, item := range c.Items {
+		if item.Function == nil || len(item.Function.Parameters) == 0 {
+			continue
+		}
+
+		if err := g.writeInput(c.Name+exportName(item.Function.Name), item.Function.Parameters); err != nil {
+			return "", err
+		}
+	}
+
+	if g.usesJSON {
+		g.types = append([]string{"scalar JSON\n"}, g.types...)
+	}
+
+	return strings.Join(g.types, "\n"), nil
+}
+
+type graphQLGenerator struct {
+	types    []string
+	usesJSON bool
+}
+
+// writeType emits a type or input named name. Nested object types are named
+// after base, which is name without any Input suffix.
+func (g *graphQLGenerator) writeType(keyword, name, base string, fields []Field) error {
+	// Reserve a slot so the outer type is emitted before nested ones
+	i := len(g.types)
+	g.types = append(g.types, "")
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s %s {\n", keyword, name)
+	for _, field := range fields {
+		t, err := g.typeOf(base+exportName(field.Name), &field.Type, keyword == "input")
+		if err != nil {
+			return err
+		}
+
+		fmt.Fprintf(&b, "%s%s: %s\n", indent, field.Name, nonNull(t, field.Required))
+	}
+	b.WriteString("}\n")
+
+	g.types[i] = b.String()
+	return nil
+}
+
+func (g *graphQLGenerator) writeInput(base string, params []Parameter) error {
+	i := len(g.types)
+	g.types = append(g.types, "")
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "input %sInput {\n", base)
+	for _, param := range params {
+		var t string
+		switch {
+		case param.Type.IsRecord(), param.Type.IsForeignRecord():
+			t = "ID"
+		default:
+			var err error
+			t, err = g.typeOf(base+exportName(param.Name), &Type{Tag: param.Type.Tag, Content: param.Type.Content}, true)
+			if err != nil {
+				return fmt.Errorf("parameter %q: %w", param.Name, err)
+			}
+		}
+
+		fmt.Fprintf(&b, "%s%s: %s\n", indent, param.Name, nonNull(t, param.Required))
+	}
+	b.WriteString("}\n")
+
+	g.types[i] = b.String()
+	return nil
+}
+
+func (g *graphQLGenerator) typeOf(name string, t *Type, input bool) (string, error) {
+	switch {
+	case t.IsString():
+		return "String", nil
+	case t.IsNumber():
+		return "Float", nil
+	case t.IsBoolean():
+		return "Boolean", nil
+	case t.IsArray():
+		elem, err := t.Array()
+		if err != nil {
+			return "", err
+		}
+		elemType, err := g.typeOf(name, elem, input)
+		if err != nil {
+			return "", err
+		}
+		return "[" + elemType + "!]", nil
+	case t.IsMap():
+		g.usesJSON = true
+		return "JSON", nil
+	case t.IsObject():
+		fields, err := t.Object()
+		if err != nil {
+			return "", err
+		}
+		// Object and input types need at least one field
+		if len(fields) == 0 {
+			g.usesJSON = true
+			return "JSON", nil
+		}
+
+		keyword, typeName := "type", name
+		if input {
+			keyword, typeName = "input", name+"Input"
+		}
+		if err := g.writeType(keyword, typeName, name, fields); err != nil {
+			return "", err
+		}
+		return typeName, nil
+	default:
+		return "", fmt.Errorf("unknown type %q", t.Tag)
+	}
+}
+
+func nonNull(t string, required bool) string {
+	if required {
+		return t + "!"
+	}
+
+	return t
+}
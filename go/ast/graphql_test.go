@@ -0,0 +1,41 @@
+package ast
+
+import (
+	"os"
+	"testing"
+)
+
+func readCollection(t *testing.T, path string) *Collection {
+	t.Helper()
+
+	src, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return mustCollection(t, string(src))
+}
+
+func readFixture(t *testing.T, path string) string {
+	t.Helper()
+
+	want, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return string(want)
+}
+
+func TestToGraphQLSDL(t *testing.T) {
+	c := readCollection(t, "testdata/user.json")
+
+	got, err := ToGraphQLSDL(c)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if want := readFixture(t, "testdata/user.graphql"); got != want {
+		t.Errorf("got:\n%s\nwant:\n%s", got, want)
+	}
+}
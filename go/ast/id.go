@@ -0,0 +1,38 @@
+package ast
+
+import (
+	"fmt"
+	"strings"
+)
+
+// IDField returns the id field declared on c, which holds the key that
+// identifies each record. The bool is false if c declares no id field.
+func (c *Collection) IDField() (*Field, bool) {
+	for _, item := range c.Items {
+		if item.Field != nil && item.Field.Name == "id" {
+			return item.Field, true
+		}
+	}
+
+	return nil, false
+}
+
+// ValidateIDField checks that c declares a required id field of type string
+// or number. The parser already rejects duplicate field names, so at most
+// one id field can exist.
+func ValidateIDField(c *Collection) error {
+	field, ok := c.IDField()
+	if !ok {
+		return fmt.Errorf("collection %q has no id field", c.Name)
+	}
+
+	if !field.Type.IsString() && !field.Type.IsNumber() {
+		return fmt.Errorf("collection %q: id field must be a string or number, not %s", c.Name, strings.ToLower(field.Type.Tag))
+	}
+
+	if !field.Required {
+		return fmt.Errorf("collection %q: id field must be required", c.Name)
+	}
+
+	return nil
+}
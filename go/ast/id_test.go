@@ -0,0 +1,58 @@
+package ast
+
+import (
+	"testing"
+)
+
+func TestValidateIDField(t *testing.T) {
+	cases := []struct {
+		name  string
+		field string
+		want  string
+	}{
+		{
+			name:  "string",
+			field: `{"Field": {"name": "id", "type_": {"tag": "String"}, "required": true}}`,
+		},
+		{
+			name:  "number",
+			field: `{"Field": {"name": "id", "type_": {"tag": "Number"}, "required": true}}`,
+		},
+		{
+			name:  "missing",
+			field: `{"Field": {"name": "key", "type_": {"tag": "String"}, "required": true}}`,
+			want:  `collection "User" has no id field`,
+		},
+		{
+			name:  "boolean",
+			field: `{"Field": {"name": "id", "type_": {"tag": "Boolean"}, "required": true}}`,
+			want:  `collection "User": id field must be a string or number, not boolean`,
+		},
+		{
+			name:  "array",
+			field: `{"Field": {"name": "id", "type_": {"tag": "Array", "content": {"tag": "String"}}, "required": true}}`,
+			want:  `collection "User": id field must be a string or number, not array`,
+		},
+		{
+			name:  "optional",
+			field: `{"Field": {"name": "id", "type_": {"tag": "String"}, "required": false}}`,
+			want:  `collection "User": id field must be required`,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := ValidateIDField(mustCollection(t, `{"name": "User", "items": [`+tc.field+`]}`))
+			if tc.want == "" {
+				if err != nil {
+					t.Errorf("unexpected error: %v", err)
+				}
+				return
+			}
+
+			if err == nil || err.Error() != tc.want {
+				t.Errorf("got error %v, want %q", err, tc.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,77 @@
+package ast
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ValidateIndexes checks that every field of every index declared in c
+// refers to a string, number or boolean field. It returns one error for
+// each invalid index.
+func ValidateIndexes(c *Collection) []error {
+	var errs []error
+	for _, item := range c.Items {
+		if item.Index == nil {
+			continue
+		}
+
+		for _, indexField := range item.Index.Fields {
+			if err := validateIndexField(c, indexField.Path); err != nil {
+				errs = append(errs, err)
+				break
+			}
+		}
+	}
+
+	return errs
+}
+
+func validateIndexField(c *Collection, path []string) error {
+	t, ok, err := c.ResolveFieldPath(path)
+	if err != nil {
+		return fmt.Errorf("invalid index on %q: %w", strings.Join(path, "."), err)
+	}
+	if !ok {
+		return fmt.Errorf("invalid index on %q: field not found", strings.Join(path, "."))
+	}
+
+	if !t.IsString() && !t.IsNumber() && !t.IsBoolean() {
+		return fmt.Errorf("invalid index on %q: cannot index a field of type %s", strings.Join(path, "."), strings.ToLower(t.Tag))
+	}
+
+	return nil
+}
+
+// ResolveFieldPath returns the type of the field at path, descending
+// through nested object fields. The bool is false if a field along the path
+// does not exist. Paths that try to descend into an array, map or scalar
+// field are an error.
+func (c *Collection) ResolveFieldPath(path []string) (Type, bool, error) {
+	if len(path) == 0 {
+		return Type{}, false, fmt.Errorf("empty field path")
+	}
+
+	fields := c.Fields()
+	for i, name := range path[:len(path)-1] {
+		field := FindField(fields, name)
+		if field == nil {
+			return Type{}, false, nil
+		}
+
+		if !field.Type.IsObject() {
+			return Type{}, false, fmt.Errorf("field %q of type %s has no subfields", strings.Join(path[:i+1], "."), strings.ToLower(field.Type.Tag))
+		}
+
+		var err error
+		if fields, err = field.Type.Object(); err != nil {
+			return Type{}, false, err
+		}
+	}
+
+	field := FindField(fields, path[len(path)-1])
+	if field == nil {
+		return Type{}, false, nil
+	}
+
+	return field.Type, true, nil
+}
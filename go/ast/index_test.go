@@ -0,0 +1,95 @@
+package ast
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+const indexCollection = `{"name": "User", "items": [
+	{"Field": {"name": "id", "type_": {"tag": "String"}, "required": true}},
+	{"Field": {"name": "age", "type_": {"tag": "Number"}, "required": false}},
+	{"Field": {"name": "tags", "type_": {"tag": "Array", "content": {"tag": "String"}}, "required": false}},
+	{"Field": {"name": "address", "type_": {"tag": "Object", "content": [
+		{"name": "city", "type_": {"tag": "String"}, "required": true}
+	]}, "required": true}}
+]}`
+
+func withIndex(t *testing.T, fields string) *Collection {
+	t.Helper()
+
+	var item CollectionItem
+	if err := json.Unmarshal([]byte(`{"Index": {"fields": `+fields+`}}`), &item); err != nil {
+		t.Fatal(err)
+	}
+
+	c := mustCollection(t, indexCollection)
+	c.Items = append(c.Items, item)
+
+	return c
+}
+
+func TestValidateIndexes(t *testing.T) {
+	cases := []struct {
+		name   string
+		fields string
+		want   string
+	}{
+		{
+			name:   "valid",
+			fields: `[{"path": ["age"], "order": "Asc"}, {"path": ["address", "city"], "order": "Desc"}]`,
+		},
+		{
+			name:   "typo",
+			fields: `[{"path": ["agee"], "order": "Asc"}]`,
+			want:   `invalid index on "agee": field not found`,
+		},
+		{
+			name:   "unsupported type",
+			fields: `[{"path": ["tags"], "order": "Asc"}]`,
+			want:   `invalid index on "tags": cannot index a field of type array`,
+		},
+		{
+			name:   "object",
+			fields: `[{"path": ["address"], "order": "Asc"}]`,
+			want:   `invalid index on "address": cannot index a field of type object`,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			errs := ValidateIndexes(withIndex(t, tc.fields))
+			if tc.want == "" {
+				if len(errs) != 0 {
+					t.Errorf("unexpected errors: %v", errs)
+				}
+				return
+			}
+
+			if len(errs) != 1 || errs[0].Error() != tc.want {
+				t.Errorf("got %v, want [%s]", errs, tc.want)
+			}
+		})
+	}
+}
+
+func TestResolveFieldPath(t *testing.T) {
+	c := mustCollection(t, indexCollection)
+
+	typ, ok, err := c.ResolveFieldPath([]string{"address", "city"})
+	if err != nil || !ok || !typ.IsString() {
+		t.Errorf("address.city: got %v, %v, %v, want string field", typ.Tag, ok, err)
+	}
+
+	if _, ok, err := c.ResolveFieldPath([]string{"address", "zip"}); ok || err != nil {
+		t.Errorf("address.zip: got %v, %v, want not found", ok, err)
+	}
+
+	if _, ok, err := c.ResolveFieldPath([]string{"profile", "name"}); ok || err != nil {
+		t.Errorf("profile.name: got %v, %v, want not found", ok, err)
+	}
+
+	_, _, err = c.ResolveFieldPath([]string{"tags", "length"})
+	if want := `field "tags" of type array has no subfields`; err == nil || err.Error() != want {
+		t.Errorf("tags.length: got error %v, want %q", err, want)
+	}
+}
@@ -0,0 +1,179 @@
+package ast
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+)
+
+// Collections returns the collections declared in p, in source order,
+// skipping standalone functions.
+func (p *Program) Collections() []*Collection {
+	var collections []*Collection
+	for _, node := range p.Nodes {
+		if node.Collection != nil {
+			collections = append(collections, node.Collection)
+		}
+	}
+
+	return collections
+}
+
+// FindCollection returns the collection in p named name.
+func (p *Program) FindCollection(name string) (*Collection, bool) {
+	for _, c := range p.Collections() {
+		if c.Name == name {
+			return c, true
+		}
+	}
+
+	return nil, false
+}
+
+// Functions returns the standalone functions declared in p, in source
+// order, skipping functions declared inside collections.
+func (p *Program) Functions() []*Function {
+	var functions []*Function
+	for _, node := range p.Nodes {
+		if node.Function != nil {
+			functions = append(functions, node.Function)
+		}
+	}
+
+	return functions
+}
+
+// MarshalCanonical encodes p as indented JSON with object keys sorted, so
+// that semantically identical programs always produce identical bytes no
+// matter how their raw JSON parts, such as type contents, were formatted.
+// Array order, including the order of declarations, is preserved.
+func MarshalCanonical(p *Program) ([]byte, error) {
+	encoded, err := json.Marshal(p)
+	if err != nil {
+		return nil, err
+	}
+
+	var decoded any
+	if err := decodeJSON(string(encoded), &decoded); err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	enc.SetIndent("", indent)
+	if err := enc.Encode(decoded); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+// Validate reports every collection in p whose name appears in reserved or
+// is already taken by an earlier collection or standalone function in p.
+// Calls such as Account(id) resolve by name, so a function sharing a
+// collection's name is a collision too.
+func (p *Program) Validate(reserved []string) []error {
+	isReserved := map[string]bool{}
+	for _, name := range reserved {
+		isReserved[name] = true
+	}
+
+	declared := map[string]string{}
+	var errs []error
+	declare := func(kind, name string) {
+		if previous, ok := declared[name]; ok {
+			errs = append(errs, fmt.Errorf("%s %q collides with %s of the same name", kind, name, previous))
+			return
+		}
+		declared[name] = kind
+	}
+
+	for _, node := range p.Nodes {
+		switch {
+		case node.Collection != nil:
+			if isReserved[node.Collection.Name] {
+				errs = append(errs, fmt.Errorf("collection name %q is reserved", node.Collection.Name))
+			}
+			declare("collection", node.Collection.Name)
+		case node.Function != nil:
+			declare("function", node.Function.Name)
+		}
+	}
+
+	return errs
+}
+
+// ResolveForeignRecords links every collection referenced from a function
+// in p to its declaration. References come from foreign record parameters
+// and from calls such as Account(id) whose callee names a collection.
+// An error is returned for each foreign record parameter naming a
+// collection that p does not declare.
+func (p *Program) ResolveForeignRecords() (map[string]*Collection, []error) {
+	collections := map[string]*Collection{}
+	for _, c := range p.Collections() {
+		collections[c.Name] = c
+	}
+
+	resolved := map[string]*Collection{}
+	var errs []error
+
+	resolveFunction := func(owner string, fn *Function) {
+		for _, param := range fn.Parameters {
+			if !param.Type.IsForeignRecord() {
+				continue
+			}
+
+			name := param.Type.ForeignRecord().Collection
+			if c, ok := collections[name]; ok {
+				resolved[name] = c
+			} else {
+				errs = append(errs, fmt.Errorf("%s: parameter %q references unknown collection %q", owner, param.Name, name))
+			}
+		}
+
+		walkCalls(fn.Statements, func(callee string) {
+			if c, ok := collections[callee]; ok {
+				resolved[callee] = c
+			}
+		})
+	}
+
+	for _, node := range p.Nodes {
+		switch {
+		case node.Collection != nil:
+			for _, item := range node.Collection.Items {
+				if item.Function != nil {
+					resolveFunction(node.Collection.Name+"."+item.Function.Name, item.Function)
+				}
+			}
+		case node.Function != nil:
+			resolveFunction(node.Function.Name, node.Function)
+		}
+	}
+
+	return resolved, errs
+}
+
+// walkCalls calls fn with the callee name of every call to a plain
+// identifier found in the JSON-decoded statements.
+func walkCalls(v any, fn func(callee string)) {
+	switch v := v.(type) {
+	case []any:
+		for _, item := range v {
+			walkCalls(item, fn)
+		}
+	case map[string]any:
+		if call, ok := v["Call"].([]any); ok && len(call) > 0 {
+			if callee, ok := call[0].(map[string]any); ok {
+				if name, ok := callee["Ident"].(string); ok {
+					fn(name)
+				}
+			}
+		}
+
+		for _, item := range v {
+			walkCalls(item, fn)
+		}
+	}
+}
@@ -0,0 +1,196 @@
+package ast
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func mustProgram(t *testing.T, src string) *Program {
+	t.Helper()
+
+	var p Program
+	if err := json.Unmarshal([]byte(src), &p); err != nil {
+		t.Fatal(err)
+	}
+
+	return &p
+}
+
+// programSource declares two collections and a standalone function. User
+// refers to Account through a foreign record parameter and lookup through
+// a call.
+const programSource = `{"nodes": [
+	{"Collection": {"name": "Account", "items": [
+		{"Field": {"name": "id", "type_": {"tag": "String"}, "required": true}},
+		{"Field": {"name": "balance", "type_": {"tag": "Number"}, "required": true}}
+	]}},
+	{"Collection": {"name": "User", "items": [
+		{"Field": {"name": "id", "type_": {"tag": "String"}, "required": true}},
+		{"Function": {
+			"name": "open",
+			"parameters": [
+				{"name": "account", "type_": {"tag": "ForeignRecord", "content": {"collection": "Account"}}, "required": true}
+			],
+			"return_type": null,
+			"statements": [],
+			"statements_code": ""
+		}}
+	]}},
+	{"Function": {
+		"name": "lookup",
+		"parameters": [{"name": "id", "type_": {"tag": "String"}, "required": true}],
+		"return_type": null,
+		"statements": [{"Return": {"Call": [{"Ident": "Account"}, [{"Ident": "id"}]]}}],
+		"statements_code": "return Account(id);"
+	}}
+]}`
+
+func TestResolveForeignRecords(t *testing.T) {
+	p := mustProgram(t, programSource)
+
+	resolved, errs := p.ResolveForeignRecords()
+	if len(errs) != 0 {
+		t.Fatalf("unexpected errors: %v", errs)
+	}
+	if len(resolved) != 1 || resolved["Account"] == nil || resolved["Account"].Name != "Account" {
+		t.Errorf("got %v, want only Account", resolved)
+	}
+}
+
+func TestResolveForeignRecordsDangling(t *testing.T) {
+	p := mustProgram(t, strings.Replace(programSource, `{"collection": "Account"}`, `{"collection": "Bank"}`, 1))
+
+	resolved, errs := p.ResolveForeignRecords()
+	want := `User.open: parameter "account" references unknown collection "Bank"`
+	if len(errs) != 1 || errs[0].Error() != want {
+		t.Errorf("got %v, want [%s]", errs, want)
+	}
+
+	// The call in lookup still resolves
+	if len(resolved) != 1 || resolved["Account"] == nil {
+		t.Errorf("got %v, want only Account", resolved)
+	}
+}
+
+func TestCollections(t *testing.T) {
+	p := mustProgram(t, programSource)
+
+	var names []string
+	for _, c := range p.Collections() {
+		names = append(names, c.Name)
+	}
+	if want := []string{"Account", "User"}; !reflect.DeepEqual(names, want) {
+		t.Errorf("got %v, want %v", names, want)
+	}
+}
+
+func TestValidate(t *testing.T) {
+	cases := []struct {
+		name     string
+		source   string
+		reserved []string
+		want     []string
+	}{
+		{
+			name:     "valid",
+			source:   programSource,
+			reserved: []string{"Collection"},
+		},
+		{
+			name:     "reserved",
+			source:   programSource,
+			reserved: []string{"User"},
+			want:     []string{`collection name "User" is reserved`},
+		},
+		{
+			name:   "duplicate collection",
+			source: strings.Replace(programSource, `"name": "User"`, `"name": "Account"`, 1),
+			want:   []string{`collection "Account" collides with collection of the same name`},
+		},
+		{
+			name:   "function named after collection",
+			source: strings.Replace(programSource, `"name": "lookup"`, `"name": "User"`, 1),
+			want:   []string{`function "User" collides with collection of the same name`},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			var got []string
+			for _, err := range mustProgram(t, tc.source).Validate(tc.reserved) {
+				got = append(got, err.Error())
+			}
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("got %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestMarshalCanonical(t *testing.T) {
+	var compact bytes.Buffer
+	if err := json.Compact(&compact, []byte(programSource)); err != nil {
+		t.Fatal(err)
+	}
+	// Reorder keys and respace the raw type content
+	reformatted := strings.Replace(compact.String(),
+		`{"tag":"ForeignRecord","content":{"collection":"Account"}}`,
+		`{"content": { "collection" : "Account" }, "tag": "ForeignRecord"}`, 1)
+	if reformatted == compact.String() {
+		t.Fatal("reformatting had no effect")
+	}
+
+	want, err := MarshalCanonical(mustProgram(t, programSource))
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := MarshalCanonical(mustProgram(t, reformatted))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !bytes.Equal(got, want) {
+		t.Errorf("canonical output differs:\n%s\nwant:\n%s", got, want)
+	}
+
+	// Each node must stay a single-key object so the parser can read it back
+	var decoded struct {
+		Nodes []map[string]json.RawMessage `json:"nodes"`
+	}
+	if err := json.Unmarshal(got, &decoded); err != nil {
+		t.Fatal(err)
+	}
+	for i, node := range decoded.Nodes {
+		if len(node) != 1 {
+			t.Errorf("node %d: got %d keys, want 1", i, len(node))
+		}
+	}
+}
+
+func TestFindCollection(t *testing.T) {
+	p := mustProgram(t, programSource)
+
+	if c, ok := p.FindCollection("User"); !ok || c.Name != "User" {
+		t.Errorf("User: got %v, %v", c, ok)
+	}
+	// Standalone functions are not collections
+	if _, ok := p.FindCollection("lookup"); ok {
+		t.Error("lookup: found as a collection")
+	}
+}
+
+func TestFunctions(t *testing.T) {
+	p := mustProgram(t, programSource)
+
+	var names []string
+	for _, fn := range p.Functions() {
+		names = append(names, fn.Name)
+	}
+	// User.open is declared inside a collection and is skipped
+	if want := []string{"lookup"}; !reflect.DeepEqual(names, want) {
+		t.Errorf("got %v, want %v", names, want)
+	}
+}
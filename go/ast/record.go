@@ -0,0 +1,315 @@
+package ast
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Fields returns the fields declared directly on c, in declaration order.
+func (c *Collection) Fields() []Field {
+	var fields []Field
+	for _, item := range c.Items {
+		if item.Field != nil {
+			fields = append(fields, *item.Field)
+		}
+	}
+
+	return fields
+}
+
+// CanonicalizeRecord re-encodes the record data so that equal records always
+// produce identical bytes. Object fields are written in the order they are
+// declared in c, map keys are sorted, fields c does not declare are dropped,
+// missing fields that declare a default are written with that default, and
+// numbers, including number map keys, are written as NormalizeNumbers writes
+// them, so 1.5 and 1.50 encode the same.
+func CanonicalizeRecord(c *Collection, data string) ([]byte, error) {
+	var record map[string]any
+	if err := decodeJSON(data, &record); err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	if err := writeObject(&buf, record, c.Fields()); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+// NormalizeNumbers re-encodes the record data with every value of a number
+// field, and every key of a map with number keys, written the way the
+// JavaScript that runs methods prints numbers, so 1e2, 100.0 and 100 all
+// become 100. Everything else, including fields c does not declare, is kept
+// as written, though object keys come out sorted.
+func NormalizeNumbers(c *Collection, data string) ([]byte, error) {
+	var record map[string]any
+	if err := decodeJSON(data, &record); err != nil {
+		return nil, err
+	}
+
+	if err := normalizeFields(record, c.Fields()); err != nil {
+		return nil, err
+	}
+
+	// json.Marshal would escape <, > and &, changing strings that were
+	// meant to be kept as written
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(record); err != nil {
+		return nil, err
+	}
+
+	return bytes.TrimSuffix(buf.Bytes(), []byte("\n")), nil
+}
+
+func normalizeFields(obj map[string]any, fields []Field) error {
+	for _, field := range fields {
+		value, ok := obj[field.Name]
+		if !ok {
+			continue
+		}
+
+		normalized, err := normalizeNumbers(value, &field.Type)
+		if err != nil {
+			return err
+		}
+		obj[field.Name] = normalized
+	}
+
+	return nil
+}
+
+// normalizeNumbers returns value with its numbers normalized as guided by t.
+// As with writeValue, values whose shape does not match t are unchanged.
+func normalizeNumbers(value any, t *Type) (any, error) {
+	switch v := value.(type) {
+	case json.Number:
+		if t.IsNumber() {
+			return canonicalNumber(string(v))
+		}
+	case []any:
+		if t.IsArray() {
+			elem, err := t.Array()
+			if err != nil {
+				return nil, err
+			}
+
+			for i, item := range v {
+				if v[i], err = normalizeNumbers(item, elem); err != nil {
+					return nil, err
+				}
+			}
+		}
+	case map[string]any:
+		switch {
+		case t.IsObject():
+			fields, err := t.Object()
+			if err != nil {
+				return nil, err
+			}
+			if err := normalizeFields(v, fields); err != nil {
+				return nil, err
+			}
+		case t.IsMap():
+			kt, vt, err := t.Map()
+			if err != nil {
+				return nil, err
+			}
+
+			normalized := make(map[string]any, len(v))
+			for key, entry := range v {
+				if kt.IsNumber() {
+					if n, err := canonicalNumber(key); err == nil {
+						key = string(n)
+					}
+				}
+
+				if _, ok := normalized[key]; ok {
+					return nil, fmt.Errorf("map keys normalize to the same number %s", key)
+				}
+				if normalized[key], err = normalizeNumbers(entry, vt); err != nil {
+					return nil, err
+				}
+			}
+			return normalized, nil
+		}
+	}
+
+	return value, nil
+}
+
+// canonicalNumber formats the number s the way the JavaScript that runs
+// methods prints it. encoding/json formats floats the same way.
+func canonicalNumber(s string) (json.Number, error) {
+	f, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return "", err
+	}
+
+	encoded, err := json.Marshal(f)
+	if err != nil {
+		return "", err
+	}
+
+	return json.Number(encoded), nil
+}
+
+// decodeJSON decodes data into v, keeping numbers as json.Number. Unlike
+// json.Unmarshal, a Decoder stops after the first value, so anything that
+// follows it is rejected explicitly.
+func decodeJSON(data string, v any) error {
+	dec := json.NewDecoder(strings.NewReader(data))
+	dec.UseNumber()
+	if err := dec.Decode(v); err != nil {
+		return err
+	}
+
+	if _, err := dec.Token(); err != io.EOF {
+		return errors.New("unexpected data after JSON value")
+	}
+
+	return nil
+}
+
+func writeObject(buf *bytes.Buffer, obj map[string]any, fields []Field) error {
+	buf.WriteByte('{')
+	first := true
+	for _, field := range fields {
+		value, ok := obj[field.Name]
+		if !ok {
+			if field.Default == nil {
+				continue
+			}
+			if err := decodeJSON(string(field.Default), &value); err != nil {
+				return fmt.Errorf("field %q: %w", field.Name, err)
+			}
+		}
+
+		if !first {
+			buf.WriteByte(',')
+		}
+		first = false
+
+		if err := writeKey(buf, field.Name); err != nil {
+			return err
+		}
+		if err := writeValue(buf, value, &field.Type); err != nil {
+			return err
+		}
+	}
+	buf.WriteByte('}')
+
+	return nil
+}
+
+func writeKey(buf *bytes.Buffer, key string) error {
+	encoded, err := json.Marshal(key)
+	if err != nil {
+		return err
+	}
+
+	buf.Write(encoded)
+	buf.WriteByte(':')
+	return nil
+}
+
+// writeValue writes value guided by t. Values whose shape does not match t
+// are written unchanged; rejecting them is left to ValidateSet.
+func writeValue(buf *bytes.Buffer, value any, t *Type) error {
+	switch v := value.(type) {
+	case map[string]any:
+		switch {
+		case t.IsObject():
+			fields, err := t.Object()
+			if err != nil {
+				return err
+			}
+			return writeObject(buf, v, fields)
+		case t.IsMap():
+			kt, vt, err := t.Map()
+			if err != nil {
+				return err
+			}
+
+			entries := make(map[string]any, len(v))
+			for key, entry := range v {
+				if kt.IsNumber() {
+					if n, err := canonicalNumber(key); err == nil {
+						key = string(n)
+					}
+				}
+
+				if _, ok := entries[key]; ok {
+					return fmt.Errorf("map keys normalize to the same number %s", key)
+				}
+				entries[key] = entry
+			}
+
+			keys := make([]string, 0, len(entries))
+			for key := range entries {
+				keys = append(keys, key)
+			}
+			sort.Strings(keys)
+
+			buf.WriteByte('{')
+			for i, key := range keys {
+				if i > 0 {
+					buf.WriteByte(',')
+				}
+				if err := writeKey(buf, key); err != nil {
+					return err
+				}
+				if err := writeValue(buf, entries[key], vt); err != nil {
+					return err
+				}
+			}
+			buf.WriteByte('}')
+			return nil
+		}
+	case []any:
+		if t.IsArray() {
+			elem, err := t.Array()
+			if err != nil {
+				return err
+			}
+
+			buf.WriteByte('[')
+			for i, item := range v {
+				if i > 0 {
+					buf.WriteByte(',')
+				}
+				if err := writeValue(buf, item, elem); err != nil {
+					return err
+				}
+			}
+			buf.WriteByte(']')
+			return nil
+		}
+	case json.Number:
+		if t.IsNumber() {
+			n, err := canonicalNumber(string(v))
+			if err != nil {
+				return err
+			}
+
+			buf.WriteString(string(n))
+			return nil
+		}
+	}
+
+	encoded, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+
+	buf.Write(encoded)
+	return nil
+}
@@ -0,0 +1,152 @@
+package ast
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func mustCollection(t *testing.T, src string) *Collection {
+	t.Helper()
+
+	var c Collection
+	if err := json.Unmarshal([]byte(src), &c); err != nil {
+		t.Fatal(err)
+	}
+
+	return &c
+}
+
+const recordCollection = `{"name": "User", "items": [
+	{"Field": {"name": "id", "type_": {"tag": "String"}, "required": true}},
+	{"Field": {"name": "name", "type_": {"tag": "String"}, "required": true}},
+	{"Field": {"name": "tags", "type_": {"tag": "Map", "content": [{"tag": "String"}, {"tag": "Number"}]}, "required": false}},
+	{"Field": {"name": "address", "type_": {"tag": "Object", "content": [
+		{"name": "city", "type_": {"tag": "String"}, "required": true},
+		{"name": "zip", "type_": {"tag": "String"}, "required": false}
+	]}, "required": false}}
+]}`
+
+func TestCanonicalizeRecord(t *testing.T) {
+	c := mustCollection(t, recordCollection)
+
+	got, err := CanonicalizeRecord(c, `{
+		"extra": 1,
+		"address": {"zip": "1", "more": true, "city": "x"},
+		"tags": {"b": 2, "a": 1.50},
+		"name": "n",
+		"id": "1"
+	}`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := `{"id":"1","name":"n","tags":{"a":1.5,"b":2},"address":{"city":"x","zip":"1"}}`
+	if string(got) != want {
+		t.Errorf("got %s, want %s", got, want)
+	}
+}
+
+func TestCanonicalizeRecordTrailingData(t *testing.T) {
+	c := mustCollection(t, recordCollection)
+
+	if _, err := CanonicalizeRecord(c, `{"id": "1"} trailing`); err == nil {
+		t.Error("expected an error for trailing data")
+	}
+}
+
+const numberCollection = `{"name": "Counter", "items": [
+	{"Field": {"name": "n", "type_": {"tag": "Number"}, "required": true}},
+	{"Field": {"name": "m", "type_": {"tag": "Map", "content": [{"tag": "Number"}, {"tag": "Number"}]}, "required": false}}
+]}`
+
+func TestCanonicalizeRecordNumbers(t *testing.T) {
+	c := mustCollection(t, numberCollection)
+
+	for _, input := range []string{
+		`{"n": 1.5, "m": {"10": 2}}`,
+		`{"n": 1.50, "m": {"10.0": 2.0}}`,
+		`{"m": {"1e1": 2e0}, "n": 15e-1}`,
+	} {
+		got, err := CanonicalizeRecord(c, input)
+		if err != nil {
+			t.Fatalf("%s: %s", input, err)
+		}
+
+		if want := `{"n":1.5,"m":{"10":2}}`; string(got) != want {
+			t.Errorf("%s: got %s, want %s", input, got, want)
+		}
+	}
+
+	if _, err := CanonicalizeRecord(c, `{"n": 1, "m": {"1": 1, "1.0": 2}}`); err == nil {
+		t.Error("expected an error for colliding map keys")
+	}
+}
+
+func TestCanonicalizeRecordDefaults(t *testing.T) {
+	c := mustCollection(t, `{"name": "Account", "items": [
+		{"Field": {"name": "id", "type_": {"tag": "String"}, "required": true}},
+		{"Field": {"name": "balance", "type_": {"tag": "Number"}, "required": false, "default": 0.0}},
+		{"Field": {"name": "settings", "type_": {"tag": "Object", "content": [
+			{"name": "currency", "type_": {"tag": "String"}, "required": false, "default": "USD"},
+			{"name": "frozen", "type_": {"tag": "Boolean"}, "required": false, "default": false}
+		]}, "required": true}},
+		{"Field": {"name": "memo", "type_": {"tag": "String"}, "required": false}}
+	]}`)
+
+	for input, want := range map[string]string{
+		`{"id": "1", "settings": {}}`:                                          `{"id":"1","balance":0,"settings":{"currency":"USD","frozen":false}}`,
+		`{"id": "1", "balance": 5, "settings": {"frozen": true}, "memo": "m"}`: `{"id":"1","balance":5,"settings":{"currency":"USD","frozen":true},"memo":"m"}`,
+	} {
+		got, err := CanonicalizeRecord(c, input)
+		if err != nil {
+			t.Fatalf("%s: %s", input, err)
+		}
+
+		if string(got) != want {
+			t.Errorf("%s: got %s, want %s", input, got, want)
+		}
+	}
+}
+
+func TestNormalizeNumbers(t *testing.T) {
+	c := mustCollection(t, numberCollection)
+
+	for _, input := range []string{`{"n": 1e2}`, `{"n": 100.0}`, `{"n": 100}`} {
+		got, err := NormalizeNumbers(c, input)
+		if err != nil {
+			t.Fatalf("%s: %s", input, err)
+		}
+
+		if want := `{"n":100}`; string(got) != want {
+			t.Errorf("%s: got %s, want %s", input, got, want)
+		}
+	}
+}
+
+func TestNormalizeNumbersMapKeys(t *testing.T) {
+	c := mustCollection(t, numberCollection)
+
+	got, err := NormalizeNumbers(c, `{"n": 1, "m": {"1e1": 2.50}}`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := `{"m":{"10":2.5},"n":1}`; string(got) != want {
+		t.Errorf("got %s, want %s", got, want)
+	}
+
+	if _, err := NormalizeNumbers(c, `{"n": 1, "m": {"1": 1, "1.0": 2}}`); err == nil {
+		t.Error("expected an error for colliding map keys")
+	}
+}
+
+func TestNormalizeNumbersKeepsStrings(t *testing.T) {
+	c := mustCollection(t, recordCollection)
+
+	got, err := NormalizeNumbers(c, `{"id": "1", "name": "<b>Tom & Jerry</b>"}`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := `{"id":"1","name":"<b>Tom & Jerry</b>"}`; string(got) != want {
+		t.Errorf("got %s, want %s", got, want)
+	}
+}
@@ -0,0 +1,22 @@
+export interface User {
+  id: string;
+  age?: number;
+  active: boolean;
+  tags?: string[];
+  balances: Record<string, number>;
+  address: UserAddress;
+  meta?: UserMeta;
+}
+
+export interface UserAddress {
+  city: string;
+  geo?: UserAddressGeo;
+}
+
+export interface UserAddressGeo {
+  lat: number;
+  lng: number;
+}
+
+export interface UserMeta {
+}
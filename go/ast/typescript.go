@@ -0,0 +1,97 @@
+package ast
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ToTypeScript generates TypeScript interfaces describing records of c.
+// Object fields become separate interfaces named after the collection and
+// the field path, e.g. field address of User becomes UserAddress.
+func ToTypeScript(c *Collection) (string, error) {
+	g := tsGenerator{}
+	if err := g.writeInterface(c.Name, c.Fields()); err != nil {
+		return "", err
+	}
+
+	return strings.Join(g.interfaces, "\n"), nil
+}
+
+type tsGenerator struct {
+	interfaces []string
+}
+
+func (g *tsGenerator) writeInterface(name string, fields []Field) error {
+	// Reserve a slot so the outer interface is emitted before nested ones
+	i := len(g.interfaces)
+	g.interfaces = append(g.interfaces, "")
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "export interface %s {\n", name)
+	for _, field := range fields {
+		t, err := g.typeOf(name+exportName(field.Name), &field.Type)
+		if err != nil {
+			return err
+		}
+
+		optional := ""
+		if !field.Required {
+			optional = "?"
+		}
+		fmt.Fprintf(&b, "%s%s%s: %s;\n", indent, field.Name, optional, t)
+	}
+	b.WriteString("}\n")
+
+	g.interfaces[i] = b.String()
+	return nil
+}
+
+func (g *tsGenerator) typeOf(name string, t *Type) (string, error) {
+	switch {
+	case t.IsString(), t.IsNumber(), t.IsBoolean():
+		return strings.ToLower(t.Tag), nil
+	case t.IsArray():
+		elem, err := t.Array()
+		if err != nil {
+			return "", err
+		}
+		elemType, err := g.typeOf(name, elem)
+		if err != nil {
+			return "", err
+		}
+		return elemType + "[]", nil
+	case t.IsMap():
+		kt, vt, err := t.Map()
+		if err != nil {
+			return "", err
+		}
+		keyType, err := g.typeOf(name, kt)
+		if err != nil {
+			return "", err
+		}
+		valueType, err := g.typeOf(name, vt)
+		if err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("Record<%s, %s>", keyType, valueType), nil
+	case t.IsObject():
+		fields, err := t.Object()
+		if err != nil {
+			return "", err
+		}
+		if err := g.writeInterface(name, fields); err != nil {
+			return "", err
+		}
+		return name, nil
+	default:
+		return "", fmt.Errorf("unknown type %q", t.Tag)
+	}
+}
+
+func exportName(name string) string {
+	if name == "" {
+		return name
+	}
+
+	return strings.ToUpper(name[:1]) + name[1:]
+}
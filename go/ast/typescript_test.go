@@ -0,0 +1,16 @@
+package ast
+
+import "testing"
+
+func TestToTypeScript(t *testing.T) {
+	c := readCollection(t, "testdata/user.json")
+
+	got, err := ToTypeScript(c)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if want := readFixture(t, "testdata/user.ts"); got != want {
+		t.Errorf("got:\n%s\nwant:\n%s", got, want)
+	}
+}
@@ -0,0 +1,197 @@
+package ast
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+func (t *Type) Array() (*Type, error) {
+	var elem Type
+	if err := json.Unmarshal(t.Content, &elem); err != nil {
+		return nil, err
+	}
+
+	return &elem, nil
+}
+
+func (t *Type) Map() (*Type, *Type, error) {
+	return mapContent(t.Content)
+}
+
+func (ft *FunctionType) Array() (*Type, error) {
+	var elem Type
+	if err := json.Unmarshal(ft.Content, &elem); err != nil {
+		return nil, err
+	}
+
+	return &elem, nil
+}
+
+func (ft *FunctionType) Map() (*Type, *Type, error) {
+	return mapContent(ft.Content)
+}
+
+func mapContent(content json.RawMessage) (*Type, *Type, error) {
+	var kv [2]Type
+	if err := json.Unmarshal(content, &kv); err != nil {
+		return nil, nil, err
+	}
+
+	return &kv[0], &kv[1], nil
+}
+
+// ValueError describes a value that does not match its declared type.
+// Path uses the same notation as ValidateSet errors, e.g. "a[0].b".
+type ValueError struct {
+	Path    string
+	Message string
+}
+
+func (e *ValueError) Error() string {
+	if e.Path == "" {
+		return e.Message
+	}
+
+	return fmt.Sprintf("%s: %s", e.Path, e.Message)
+}
+
+// ValidateValue checks a value decoded by encoding/json (string, float64,
+// bool, []any, map[string]any) against t.
+func (t *Type) ValidateValue(value any) error {
+	return validateValue("", value, t)
+}
+
+// ValidateValue checks a value decoded by encoding/json against ft.
+// Records and foreign records are expected to be objects with a string id.
+func (ft *FunctionType) ValidateValue(value any) error {
+	switch {
+	case ft.IsRecord(), ft.IsForeignRecord():
+		obj, ok := value.(map[string]any)
+		if !ok {
+			return &ValueError{Message: "expected record"}
+		}
+		if _, ok := obj["id"].(string); !ok {
+			return &ValueError{Path: "id", Message: "expected string"}
+		}
+		return nil
+	default:
+		return validateValue("", value, &Type{Tag: ft.Tag, Content: ft.Content})
+	}
+}
+
+func validateValue(path string, value any, t *Type) error {
+	invalid := func() error {
+		return &ValueError{Path: path, Message: "expected " + strings.ToLower(t.Tag)}
+	}
+
+	switch {
+	case t.IsString():
+		if _, ok := value.(string); !ok {
+			return invalid()
+		}
+	case t.IsNumber():
+		if _, ok := value.(float64); !ok {
+			return invalid()
+		}
+	case t.IsBoolean():
+		if _, ok := value.(bool); !ok {
+			return invalid()
+		}
+	case t.IsArray():
+		arr, ok := value.([]any)
+		if !ok {
+			return invalid()
+		}
+
+		elem, err := t.Array()
+		if err != nil {
+			return err
+		}
+
+		for i, item := range arr {
+			if err := validateValue(fmt.Sprintf("%s[%d]", path, i), item, elem); err != nil {
+				return err
+			}
+		}
+	case t.IsMap():
+		m, ok := value.(map[string]any)
+		if !ok {
+			return invalid()
+		}
+
+		kt, vt, err := t.Map()
+		if err != nil {
+			return err
+		}
+
+		for key, item := range m {
+			itemPath := JoinPath(path, key)
+			if kt.IsNumber() {
+				if _, err := strconv.ParseFloat(key, 64); err != nil {
+					return &ValueError{Path: itemPath, Message: "expected number key"}
+				}
+			}
+
+			if err := validateValue(itemPath, item, vt); err != nil {
+				return err
+			}
+		}
+	case t.IsObject():
+		obj, ok := value.(map[string]any)
+		if !ok {
+			return invalid()
+		}
+
+		fields, err := t.Object()
+		if err != nil {
+			return err
+		}
+
+		for _, field := range fields {
+			fieldValue, ok := obj[field.Name]
+			if !ok {
+				if field.Required {
+					return &ValueError{Path: JoinPath(path, field.Name), Message: "missing field"}
+				}
+				continue
+			}
+
+			if err := validateValue(JoinPath(path, field.Name), fieldValue, &field.Type); err != nil {
+				return err
+			}
+		}
+
+		for key := range obj {
+			if FindField(fields, key) == nil {
+				return &ValueError{Path: JoinPath(path, key), Message: "extra field"}
+			}
+		}
+	default:
+		return fmt.Errorf("unknown type %q", t.Tag)
+	}
+
+	return nil
+}
+
+// FindField returns the field in fields named name, or nil.
+func FindField(fields []Field, name string) *Field {
+	for i := range fields {
+		if fields[i].Name == name {
+			return &fields[i]
+		}
+	}
+
+	return nil
+}
+
+// JoinPath appends the field or key name to path, in the notation used by
+// ValueError paths, e.g. address.city. An empty path yields name.
+func JoinPath(path, name string) string {
+	if path == "" {
+		return name
+	}
+
+	return path + "." + name
+}
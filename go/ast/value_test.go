@@ -0,0 +1,82 @@
+package ast
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestValidateValue(t *testing.T) {
+	cases := []struct {
+		name  string
+		typ   string
+		value any
+		want  *ValueError
+	}{
+		{
+			name:  "number",
+			typ:   `{"tag": "Number"}`,
+			value: 1.5,
+		},
+		{
+			name:  "wrong scalar",
+			typ:   `{"tag": "Number"}`,
+			value: "1.5",
+			want:  &ValueError{Message: "expected number"},
+		},
+		{
+			name:  "array element",
+			typ:   `{"tag": "Array", "content": {"tag": "String"}}`,
+			value: []any{"a", true},
+			want:  &ValueError{Path: "[1]", Message: "expected string"},
+		},
+		{
+			name:  "map number key",
+			typ:   `{"tag": "Map", "content": [{"tag": "Number"}, {"tag": "Boolean"}]}`,
+			value: map[string]any{"one": true},
+			want:  &ValueError{Path: "one", Message: "expected number key"},
+		},
+		{
+			name:  "missing field",
+			typ:   `{"tag": "Object", "content": [{"name": "city", "type_": {"tag": "String"}, "required": true}]}`,
+			value: map[string]any{},
+			want:  &ValueError{Path: "city", Message: "missing field"},
+		},
+		{
+			name:  "optional field omitted",
+			typ:   `{"tag": "Object", "content": [{"name": "city", "type_": {"tag": "String"}, "required": false}]}`,
+			value: map[string]any{},
+		},
+		{
+			name:  "extra field",
+			typ:   `{"tag": "Object", "content": [{"name": "city", "type_": {"tag": "String"}, "required": true}]}`,
+			value: map[string]any{"city": "x", "zip": "y"},
+			want:  &ValueError{Path: "zip", Message: "extra field"},
+		},
+		{
+			name: "nested path",
+			typ: `{"tag": "Object", "content": [{"name": "geo", "type_": {"tag": "Object", "content": [
+				{"name": "lat", "type_": {"tag": "Number"}, "required": true}
+			]}, "required": true}]}`,
+			value: map[string]any{"geo": map[string]any{"lat": "north"}},
+			want:  &ValueError{Path: "geo.lat", Message: "expected number"},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			typ := mustType(t, tc.typ)
+			err := typ.ValidateValue(tc.value)
+			if tc.want == nil {
+				if err != nil {
+					t.Errorf("unexpected error: %v", err)
+				}
+				return
+			}
+
+			var got *ValueError
+			if !errors.As(err, &got) || *got != *tc.want {
+				t.Errorf("got %v, want %v", err, tc.want)
+			}
+		})
+	}
+}
@@ -4,28 +4,41 @@ import (
 	"encoding/json"
 	"log"
 
+	"github.com/polybase/polylang/ast"
 	"github.com/polybase/polylang/parser"
 )
 
-type Program struct {
-	Nodes []struct {
-		Collection json.RawMessage `json:"Collection"`
-	} `json:"nodes"`
-}
-
 func main() {
-	parseResult, err := parser.Parse("collection Test { name: string!; }")
+	parseResult, err := parser.Parse(`
+		collection Account { id: string; balance: number; }
+		collection Test { id: string; name: string; }
+		function double(x: number) { return x * 2; }
+	`)
 	if err != nil {
 		panic(err)
 	}
 	log.Println(string(parseResult))
 
-	var ast Program
-	if err := json.Unmarshal([]byte(parseResult), &ast); err != nil {
+	var program ast.Program
+	if err := json.Unmarshal([]byte(parseResult), &program); err != nil {
+		panic(err)
+	}
+
+	for _, fn := range program.Functions() {
+		log.Println("function", fn.Name)
+	}
+
+	collection, ok := program.FindCollection("Test")
+	if !ok {
+		panic("collection Test not found")
+	}
+
+	collectionAST, err := json.Marshal(collection)
+	if err != nil {
 		panic(err)
 	}
 
-	err = parser.ValidateSet(string(ast.Nodes[0].Collection), `{ "name": 42.0 }`)
+	err = parser.ValidateSet(string(collectionAST), `{ "id": "1", "name": 42.0 }`)
 	if err == nil {
 		panic("no error from ValidateSet")
 	}
@@ -0,0 +1,56 @@
+package parser
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/polybase/polylang/ast"
+)
+
+// Analyze parses program and reports which collections and fields of this
+// the function funcName in collection references.
+func Analyze(program, collection, funcName string) (ast.Analysis, error) {
+	p, fn, err := parseFunction(program, collection, funcName)
+	if err != nil {
+		return ast.Analysis{}, err
+	}
+
+	return ast.Analyze(p, fn), nil
+}
+
+func parseProgram(program string) (*ast.Program, error) {
+	programAST, err := Parse(program)
+	if err != nil {
+		return nil, err
+	}
+
+	var p ast.Program
+	if err := json.Unmarshal(programAST, &p); err != nil {
+		return nil, fmt.Errorf("failed to parse program AST: %w", err)
+	}
+
+	return &p, nil
+}
+
+func parseFunction(program, collection, funcName string) (*ast.Program, *ast.Function, error) {
+	p, err := parseProgram(program)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	for _, c := range p.Collections() {
+		if c.Name != collection {
+			continue
+		}
+
+		for _, item := range c.Items {
+			if item.Function != nil && item.Function.Name == funcName {
+				return p, item.Function, nil
+			}
+		}
+
+		return nil, nil, fmt.Errorf("function %q not found in collection %q", funcName, collection)
+	}
+
+	return nil, nil, fmt.Errorf("collection %q not found", collection)
+}
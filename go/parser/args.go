@@ -0,0 +1,139 @@
+package parser
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/polybase/polylang/ast"
+)
+
+// EncodeArgs checks values against the parameters of fn and encodes them as
+// the JSON array of arguments a call to fn expects. Trailing optional
+// parameters may be omitted. Omitted or nil arguments for parameters with a
+// default are replaced by the default, since the generated JavaScript only
+// applies defaults to undefined, never to null.
+func EncodeArgs(fn *ast.Function, values ...any) (string, error) {
+	minArgs := 0
+	for i, param := range fn.Parameters {
+		if param.Required {
+			minArgs = i + 1
+		}
+	}
+
+	if len(values) < minArgs || len(values) > len(fn.Parameters) {
+		if minArgs == len(fn.Parameters) {
+			return "", fmt.Errorf("%s expects %d arguments, got %d", fn.Name, minArgs, len(values))
+		}
+		return "", fmt.Errorf("%s expects %d to %d arguments, got %d", fn.Name, minArgs, len(fn.Parameters), len(values))
+	}
+
+	args := make([]json.RawMessage, len(values))
+	for i, value := range values {
+		param := fn.Parameters[i]
+
+		encoded, err := json.Marshal(value)
+		if err != nil {
+			return "", fmt.Errorf("parameter %q: %w", param.Name, err)
+		}
+
+		var decoded any
+		if err := json.Unmarshal(encoded, &decoded); err != nil {
+			return "", fmt.Errorf("parameter %q: %w", param.Name, err)
+		}
+
+		if decoded == nil && param.Default != nil {
+			args[i] = param.Default
+			continue
+		}
+
+		if decoded != nil || param.Required {
+			if err := param.Type.ValidateValue(decoded); err != nil {
+				return "", fmt.Errorf("parameter %q: %w", param.Name, err)
+			}
+		}
+
+		args[i] = encoded
+	}
+
+	lastDefault := -1
+	for i := len(values); i < len(fn.Parameters); i++ {
+		if fn.Parameters[i].Default != nil {
+			lastDefault = i
+		}
+	}
+	for i := len(values); i <= lastDefault; i++ {
+		if param := fn.Parameters[i]; param.Default != nil {
+			args = append(args, param.Default)
+		} else {
+			args = append(args, json.RawMessage("null"))
+		}
+	}
+
+	output, err := json.Marshal(args)
+	if err != nil {
+		return "", err
+	}
+
+	return string(output), nil
+}
+
+// FieldError reports a problem with one named input, such as an argument
+// or a record field. Field is empty when the problem is not specific to one.
+type FieldError struct {
+	Field   string
+	Message string
+}
+
+func (e *FieldError) Error() string {
+	if e.Field == "" {
+		return e.Message
+	}
+
+	return fmt.Sprintf("%s: %s", e.Field, e.Message)
+}
+
+// ValidateArgs checks the JSON array args against the parameters of the
+// function funcName in collectionAST without running it. Omitted trailing
+// optional arguments and null optional arguments are accepted.
+func ValidateArgs(collectionAST, funcName, args string) []FieldError {
+	var collection ast.Collection
+	if err := json.Unmarshal([]byte(collectionAST), &collection); err != nil {
+		return []FieldError{{Message: fmt.Sprintf("failed to parse collection AST: %s", err)}}
+	}
+
+	var fn *ast.Function
+	for _, item := range collection.Items {
+		if item.Function != nil && item.Function.Name == funcName {
+			fn = item.Function
+			break
+		}
+	}
+	if fn == nil {
+		return []FieldError{{Message: fmt.Sprintf("function %q not found in collection %q", funcName, collection.Name)}}
+	}
+
+	var values []any
+	if err := json.Unmarshal([]byte(args), &values); err != nil {
+		return []FieldError{{Message: fmt.Sprintf("args must be a JSON array: %s", err)}}
+	}
+
+	var errs []FieldError
+	for i, param := range fn.Parameters {
+		if i >= len(values) || values[i] == nil {
+			if param.Required {
+				errs = append(errs, FieldError{Field: param.Name, Message: "missing required argument"})
+			}
+			continue
+		}
+
+		if err := param.Type.ValidateValue(values[i]); err != nil {
+			errs = append(errs, FieldError{Field: param.Name, Message: err.Error()})
+		}
+	}
+
+	for i := len(fn.Parameters); i < len(values); i++ {
+		errs = append(errs, FieldError{Field: fmt.Sprintf("[%d]", i), Message: fmt.Sprintf("unexpected argument, %s takes %d", fn.Name, len(fn.Parameters))})
+	}
+
+	return errs
+}
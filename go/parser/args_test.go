@@ -0,0 +1,133 @@
+package parser
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/polybase/polylang/ast"
+)
+
+func transferFunction(t *testing.T) *ast.Function {
+	t.Helper()
+
+	var collection ast.Collection
+	if err := json.Unmarshal([]byte(accountCollection), &collection); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, item := range collection.Items {
+		if item.Function != nil && item.Function.Name == "transfer" {
+			return item.Function
+		}
+	}
+
+	t.Fatal("transfer not found")
+	return nil
+}
+
+func TestEncodeArgs(t *testing.T) {
+	to := map[string]any{"id": "2"}
+
+	cases := []struct {
+		name   string
+		values []any
+		want   string
+	}{
+		{name: "all arguments", values: []any{to, 10, "rent"}, want: `[{"id":"2"},10,"rent"]`},
+		{name: "default omitted", values: []any{to, 10}, want: `[{"id":"2"},10,"none"]`},
+		{name: "default nil", values: []any{to, 10, nil}, want: `[{"id":"2"},10,"none"]`},
+	}
+
+	fn := transferFunction(t)
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := EncodeArgs(fn, tc.values...)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != tc.want {
+				t.Errorf("got %s, want %s", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestEncodeArgsErrors(t *testing.T) {
+	to := map[string]any{"id": "2"}
+
+	cases := []struct {
+		name   string
+		values []any
+		want   string
+	}{
+		{name: "too few", values: []any{to}, want: "transfer expects 2 to 3 arguments, got 1"},
+		{name: "too many", values: []any{to, 10, "rent", true}, want: "transfer expects 2 to 3 arguments, got 4"},
+		{name: "type mismatch", values: []any{to, "10"}, want: `parameter "amount": expected number`},
+		{name: "record as id", values: []any{"2", 10}, want: `parameter "to": expected record`},
+	}
+
+	fn := transferFunction(t)
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := EncodeArgs(fn, tc.values...)
+			if err == nil || err.Error() != tc.want {
+				t.Errorf("got error %v, want %q", err, tc.want)
+			}
+		})
+	}
+}
+
+func TestValidateArgs(t *testing.T) {
+	cases := []struct {
+		name string
+		args string
+		want []FieldError
+	}{
+		{
+			name: "correct",
+			args: `[{"id": "2"}, 10, "rent"]`,
+			want: nil,
+		},
+		{
+			name: "optional omitted",
+			args: `[{"id": "2"}, 10]`,
+			want: nil,
+		},
+		{
+			name: "missing required",
+			args: `[{"id": "2"}]`,
+			want: []FieldError{{Field: "amount", Message: "missing required argument"}},
+		},
+		{
+			name: "wrong type",
+			args: `[{"id": "2"}, "10"]`,
+			want: []FieldError{{Field: "amount", Message: "expected number"}},
+		},
+		{
+			name: "record without id",
+			args: `[{}, 10]`,
+			want: []FieldError{{Field: "to", Message: "id: expected string"}},
+		},
+		{
+			name: "extra argument",
+			args: `[{"id": "2"}, 10, "rent", true]`,
+			want: []FieldError{{Field: "[3]", Message: "unexpected argument, transfer takes 3"}},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := ValidateArgs(accountCollection, "transfer", tc.args); !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("got %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestValidateArgsUnknownFunction(t *testing.T) {
+	want := []FieldError{{Message: `function "withdraw" not found in collection "Account"`}}
+	if got := ValidateArgs(accountCollection, "withdraw", `[]`); !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,32 @@
+//go:build cgo
+
+package parser
+
+/*
+#cgo darwin LDFLAGS: ${SRCDIR}/libpolylang.a
+#cgo linux,!polylang_static LDFLAGS: -lpolylang
+#cgo linux,amd64,polylang_static LDFLAGS: ${SRCDIR}/libpolylang_linux_amd64.a -ldl -lm -lpthread
+#cgo linux,arm64,polylang_static LDFLAGS: ${SRCDIR}/libpolylang_linux_arm64.a -ldl -lm -lpthread
+#include "./polylang.h"
+*/
+import "C"
+
+func callParse(input string) (string, error) {
+	return C.GoString(C.parse(C.CString(input))), nil
+}
+
+func callValidateSet(collectionAST, data string) (string, error) {
+	return C.GoString(C.validate_set(C.CString(collectionAST), C.CString(data))), nil
+}
+
+func callGenerateJSCollection(collectionAST string) (string, error) {
+	return C.GoString(C.generate_js_collection(C.CString(collectionAST))), nil
+}
+
+func callTokenize(input string) (string, error) {
+	return C.GoString(C.tokenize(C.CString(input))), nil
+}
+
+func callVersion() (string, error) {
+	return C.GoString(C.version()), nil
+}
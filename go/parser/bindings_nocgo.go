@@ -0,0 +1,23 @@
+//go:build !cgo
+
+package parser
+
+func callParse(input string) (string, error) {
+	return "", ErrCgoUnavailable
+}
+
+func callValidateSet(collectionAST, data string) (string, error) {
+	return "", ErrCgoUnavailable
+}
+
+func callGenerateJSCollection(collectionAST string) (string, error) {
+	return "", ErrCgoUnavailable
+}
+
+func callTokenize(input string) (string, error) {
+	return "", ErrCgoUnavailable
+}
+
+func callVersion() (string, error) {
+	return "", ErrCgoUnavailable
+}
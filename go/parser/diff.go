@@ -0,0 +1,67 @@
+package parser
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+
+	"github.com/polybase/polylang/ast"
+)
+
+// ChangedFields returns the paths of the values that differ between two
+// record JSONs, such as this before and after a method call. Paths use the
+// same notation as ast.ValueError, e.g. address.city and tags[1]. Objects
+// and maps are compared key by key and arrays element by element; an array
+// whose length changed is reported as a whole.
+func ChangedFields(before, after string) ([]string, error) {
+	var b, a map[string]any
+	if err := json.Unmarshal([]byte(before), &b); err != nil {
+		return nil, fmt.Errorf("before: %w", err)
+	}
+	if err := json.Unmarshal([]byte(after), &a); err != nil {
+		return nil, fmt.Errorf("after: %w", err)
+	}
+	if b == nil || a == nil {
+		return nil, errors.New("record must be a JSON object")
+	}
+
+	var changed []string
+	diffValues("", b, a, &changed)
+	sort.Strings(changed)
+
+	return changed, nil
+}
+
+func diffValues(path string, before, after any, changed *[]string) {
+	switch b := before.(type) {
+	case map[string]any:
+		if a, ok := after.(map[string]any); ok {
+			for key, value := range b {
+				if afterValue, ok := a[key]; ok {
+					diffValues(ast.JoinPath(path, key), value, afterValue, changed)
+				} else {
+					*changed = append(*changed, ast.JoinPath(path, key))
+				}
+			}
+			for key := range a {
+				if _, ok := b[key]; !ok {
+					*changed = append(*changed, ast.JoinPath(path, key))
+				}
+			}
+			return
+		}
+	case []any:
+		if a, ok := after.([]any); ok && len(a) == len(b) {
+			for i := range b {
+				diffValues(fmt.Sprintf("%s[%d]", path, i), b[i], a[i], changed)
+			}
+			return
+		}
+	}
+
+	if !reflect.DeepEqual(before, after) {
+		*changed = append(*changed, path)
+	}
+}
@@ -0,0 +1,69 @@
+package parser
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestChangedFields(t *testing.T) {
+	cases := []struct {
+		name          string
+		before, after string
+		want          []string
+	}{
+		{
+			name:   "unchanged",
+			before: `{"id": "1", "balance": 10}`,
+			after:  `{"balance": 10, "id": "1"}`,
+			want:   nil,
+		},
+		{
+			name:   "scalar",
+			before: `{"id": "1", "balance": 10}`,
+			after:  `{"id": "1", "balance": 5}`,
+			want:   []string{"balance"},
+		},
+		{
+			name:   "nested object",
+			before: `{"address": {"city": "London", "zip": "N1"}}`,
+			after:  `{"address": {"city": "Paris", "zip": "N1"}}`,
+			want:   []string{"address.city"},
+		},
+		{
+			name:   "array element",
+			before: `{"tags": ["a", "b", "c"]}`,
+			after:  `{"tags": ["a", "x", "c"]}`,
+			want:   []string{"tags[1]"},
+		},
+		{
+			name:   "array length",
+			before: `{"tags": ["a"]}`,
+			after:  `{"tags": ["a", "b"]}`,
+			want:   []string{"tags"},
+		},
+		{
+			name:   "added and removed keys",
+			before: `{"a": 1, "m": {"x": 1}}`,
+			after:  `{"b": 1, "m": {"y": 1}}`,
+			want:   []string{"a", "b", "m.x", "m.y"},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := ChangedFields(tc.before, tc.after)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("got %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestChangedFieldsNotObject(t *testing.T) {
+	if _, err := ChangedFields(`null`, `{}`); err == nil {
+		t.Error("expected an error for a non-object record")
+	}
+}
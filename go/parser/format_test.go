@@ -0,0 +1,68 @@
+//go:build cgo
+
+package parser
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/polybase/polylang/ast"
+)
+
+func parseCollection(t *testing.T, source, name string) *ast.Collection {
+	t.Helper()
+
+	program, err := parseProgram(source)
+	if err != nil {
+		t.Fatalf("parse %q: %s", source, err)
+	}
+
+	c, ok := program.FindCollection(name)
+	if !ok {
+		t.Fatalf("collection %q not found", name)
+	}
+
+	return c
+}
+
+func TestFormatRoundTrip(t *testing.T) {
+	source := `
+		collection Account {
+			id: string;
+			name: string @regex('^[a-z]+$');
+			age: number @min(0) @max(150);
+			address?: { city: string; geo: { lat: number; }; };
+			@index(id, [address.city, desc]);
+
+			function deposit(amount: number, memo?: string, note: string = 'none') {
+				this.id = memo;
+			}
+		}
+	`
+
+	first, err := ast.Format(parseCollection(t, source, "Account"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	second, err := ast.Format(parseCollection(t, first, "Account"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if first != second {
+		t.Errorf("Format is not idempotent:\n%s\nthen:\n%s", first, second)
+	}
+
+	before, err := json.Marshal(parseCollection(t, source, "Account").Fields())
+	if err != nil {
+		t.Fatal(err)
+	}
+	after, err := json.Marshal(parseCollection(t, first, "Account").Fields())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(before) != string(after) {
+		t.Errorf("fields changed after formatting: %s != %s", before, after)
+	}
+}
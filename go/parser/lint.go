@@ -0,0 +1,302 @@
+package parser
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/polybase/polylang/ast"
+)
+
+type Severity string
+
+const (
+	SeverityError   Severity = "error"
+	SeverityWarning Severity = "warning"
+)
+
+// Diagnostic is a problem found in a program. Line is 1-based and Column is
+// a 0-based byte offset, as in Error. Line is zero only when the problem has
+// no source position, as is the case for anything found in function bodies:
+// the AST does not record statement positions.
+type Diagnostic struct {
+	Line     int
+	Column   int
+	Severity Severity
+	Message  string
+}
+
+// Lint reports unused parameters, locals that are never read, statements
+// that follow an unconditional return, throw, break or continue, and loops
+// whose condition is literally true with no way out of them. All findings
+// are warnings; the program is still valid. Names are matched without regard
+// to scope, so a local shadowing a parameter counts as a use of both.
+func Lint(program string) ([]Diagnostic, error) {
+	p, err := parseProgram(program)
+	if err != nil {
+		return nil, err
+	}
+
+	var diagnostics []Diagnostic
+	for _, node := range p.Nodes {
+		switch {
+		case node.Collection != nil:
+			for _, item := range node.Collection.Items {
+				if item.Function != nil {
+					diagnostics = append(diagnostics, lintFunction(node.Collection.Name+"."+item.Function.Name, item.Function)...)
+				}
+			}
+		case node.Function != nil:
+			diagnostics = append(diagnostics, lintFunction(node.Function.Name, node.Function)...)
+		}
+	}
+
+	return diagnostics, nil
+}
+
+func lintFunction(owner string, fn *ast.Function) []Diagnostic {
+	var diagnostics []Diagnostic
+	warn := func(format string, args ...any) {
+		diagnostics = append(diagnostics, Diagnostic{
+			Severity: SeverityWarning,
+			Message:  owner + ": " + fmt.Sprintf(format, args...),
+		})
+	}
+
+	reads := map[string]bool{}
+	collectReads(fn.Statements, reads)
+
+	for _, param := range fn.Parameters {
+		if !reads[param.Name] {
+			warn("parameter %q is never used", param.Name)
+		}
+	}
+
+	var locals []string
+	collectLocals(fn.Statements, &locals)
+	for _, local := range locals {
+		if !reads[local] {
+			warn("local %q is assigned but never read", local)
+		}
+	}
+
+	lintStatements(fn.Statements, func(after string) {
+		warn("unreachable code after %s", after)
+	})
+
+	lintLoops(fn.Statements, func(kind string) {
+		warn("%s loop never terminates", kind)
+	})
+
+	return diagnostics
+}
+
+// collectReads records every identifier read in the JSON-decoded
+// statements. The target of a plain assignment is not a read.
+func collectReads(v any, reads map[string]bool) {
+	switch v := v.(type) {
+	case []any:
+		for _, item := range v {
+			collectReads(item, reads)
+		}
+	case map[string]any:
+		if name, ok := v["Ident"].(string); ok {
+			reads[name] = true
+			return
+		}
+
+		if operands, ok := v["Assign"].([]any); ok && len(operands) == 2 {
+			if target, ok := operands[0].(map[string]any); !ok || target["Ident"] == nil {
+				collectReads(operands[0], reads)
+			}
+			collectReads(operands[1], reads)
+			return
+		}
+
+		for _, item := range v {
+			collectReads(item, reads)
+		}
+	}
+}
+
+func collectLocals(v any, locals *[]string) {
+	switch v := v.(type) {
+	case []any:
+		for _, item := range v {
+			collectLocals(item, locals)
+		}
+	case map[string]any:
+		if let, ok := v["Let"].(map[string]any); ok {
+			if name, ok := let["identifier"].(string); ok {
+				*locals = append(*locals, name)
+			}
+		}
+
+		for _, item := range v {
+			collectLocals(item, locals)
+		}
+	}
+}
+
+// lintStatements calls unreachable once for every statement list, at any
+// nesting depth, that continues past a return, throw, break or continue.
+func lintStatements(statements []any, unreachable func(after string)) {
+	for i, statement := range statements {
+		if terminator := terminatorName(statement); terminator != "" && i < len(statements)-1 {
+			unreachable(terminator)
+			break
+		}
+	}
+
+	for _, statement := range statements {
+		compound, ok := statement.(map[string]any)
+		if !ok {
+			continue
+		}
+
+		for _, kind := range []string{"If", "While", "For"} {
+			body, ok := compound[kind].(map[string]any)
+			if !ok {
+				continue
+			}
+
+			for _, key := range []string{"then_statements", "else_statements", "statements"} {
+				if nested, ok := body[key].([]any); ok {
+					lintStatements(nested, unreachable)
+				}
+			}
+		}
+	}
+}
+
+func terminatorName(statement any) string {
+	switch statement := statement.(type) {
+	case string:
+		switch statement {
+		case "Break":
+			return "break"
+		case "Continue":
+			return "continue"
+		}
+	case map[string]any:
+		if _, ok := statement["LabeledBreak"]; ok {
+			return "break"
+		}
+		if _, ok := statement["LabeledContinue"]; ok {
+			return "continue"
+		}
+		if _, ok := statement["Return"]; ok {
+			return "return"
+		}
+		if _, ok := statement["Throw"]; ok {
+			return "throw"
+		}
+	}
+
+	return ""
+}
+
+// lintLoops calls infinite for every while or for loop, at any nesting
+// depth, whose condition is the literal true and whose body has no
+// reachable break, return or throw. This is deliberately conservative:
+// loops that only end through their condition are never flagged.
+func lintLoops(statements []any, infinite func(kind string)) {
+	for _, statement := range statements {
+		compound, ok := statement.(map[string]any)
+		if !ok {
+			continue
+		}
+
+		for _, kind := range []string{"While", "For"} {
+			loop, ok := compound[kind].(map[string]any)
+			if !ok {
+				continue
+			}
+
+			body, _ := loop["statements"].([]any)
+			if isTrue(loop["condition"]) && !exits(body, true, nil) {
+				infinite(strings.ToLower(kind))
+			}
+			lintLoops(body, infinite)
+		}
+
+		if branches, ok := compound["If"].(map[string]any); ok {
+			for _, key := range []string{"then_statements", "else_statements"} {
+				if nested, ok := branches[key].([]any); ok {
+					lintLoops(nested, infinite)
+				}
+			}
+		}
+	}
+}
+
+// exits reports whether statements contain a return or throw, or a break
+// that leaves the loop being checked. Inside loops nested in it, whose labels
+// are in nested, breakExits is false: a plain break only leaves the nested
+// loop, and so does a labeled break naming one of them.
+func exits(statements []any, breakExits bool, nested []string) bool {
+	for _, statement := range statements {
+		switch terminatorName(statement) {
+		case "return", "throw":
+			return true
+		case "break":
+			if label, ok := breakLabel(statement); ok {
+				if !contains(nested, label) {
+					return true
+				}
+			} else if breakExits {
+				return true
+			}
+		}
+
+		compound, ok := statement.(map[string]any)
+		if !ok {
+			continue
+		}
+
+		if branches, ok := compound["If"].(map[string]any); ok {
+			for _, key := range []string{"then_statements", "else_statements"} {
+				if branch, ok := branches[key].([]any); ok && exits(branch, breakExits, nested) {
+					return true
+				}
+			}
+		}
+
+		for _, kind := range []string{"While", "For"} {
+			if loop, ok := compound[kind].(map[string]any); ok {
+				labels := nested
+				if label, ok := loop["label"].(string); ok {
+					labels = append(labels[:len(labels):len(labels)], label)
+				}
+				if body, ok := loop["statements"].([]any); ok && exits(body, false, labels) {
+					return true
+				}
+			}
+		}
+	}
+
+	return false
+}
+
+func breakLabel(statement any) (string, bool) {
+	if statement, ok := statement.(map[string]any); ok {
+		label, ok := statement["LabeledBreak"].(string)
+		return label, ok
+	}
+
+	return "", false
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+
+	return false
+}
+
+func isTrue(condition any) bool {
+	expr, ok := condition.(map[string]any)
+	return ok && expr["Boolean"] == true
+}
@@ -0,0 +1,116 @@
+package parser
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/polybase/polylang/ast"
+)
+
+// lintMessages lints a function with the given parameters and statements,
+// both as AST JSON, and returns the messages reported.
+func lintMessages(t *testing.T, parameters, statements string) []string {
+	t.Helper()
+
+	var fn ast.Function
+	src := `{"name": "f", "parameters": ` + parameters + `, "return_type": null, "statements": ` + statements + `, "statements_code": ""}`
+	if err := json.Unmarshal([]byte(src), &fn); err != nil {
+		t.Fatal(err)
+	}
+
+	var messages []string
+	for _, d := range lintFunction("Account.f", &fn) {
+		if d.Severity != SeverityWarning {
+			t.Errorf("%s: got severity %s, want warning", d.Message, d.Severity)
+		}
+		messages = append(messages, d.Message)
+	}
+
+	return messages
+}
+
+const paramX = `[{"name": "x", "type_": {"tag": "Number"}, "required": true}]`
+
+func TestLintFunction(t *testing.T) {
+	cases := []struct {
+		name       string
+		parameters string
+		statements string
+		want       []string
+	}{
+		{
+			name:       "clean",
+			parameters: paramX,
+			statements: `[{"Return": {"Ident": "x"}}]`,
+		},
+		{
+			name:       "unused parameter",
+			parameters: paramX,
+			statements: `[]`,
+			want:       []string{`Account.f: parameter "x" is never used`},
+		},
+		{
+			name:       "unused local",
+			parameters: `[]`,
+			statements: `[{"Let": {"identifier": "y", "expression": {"Boolean": true}}}]`,
+			want:       []string{`Account.f: local "y" is assigned but never read`},
+		},
+		{
+			name:       "statement after return",
+			parameters: paramX,
+			statements: `[{"Return": {"Ident": "x"}}, {"Expression": {"Ident": "x"}}]`,
+			want:       []string{"Account.f: unreachable code after return"},
+		},
+		{
+			name:       "infinite loop",
+			parameters: `[]`,
+			statements: `[{"While": {"condition": {"Boolean": true}, "statements": []}}]`,
+			want:       []string{"Account.f: while loop never terminates"},
+		},
+		{
+			name:       "loop with break",
+			parameters: `[]`,
+			statements: `[{"While": {"condition": {"Boolean": true}, "statements": ["Break"]}}]`,
+		},
+		{
+			name:       "break only leaves inner loop",
+			parameters: `[]`,
+			statements: `[{"While": {"condition": {"Boolean": true}, "statements": [
+				{"While": {"condition": {"Ident": "x"}, "statements": ["Break"]}}
+			]}}]`,
+			want: []string{"Account.f: while loop never terminates"},
+		},
+		{
+			name:       "labeled break leaves outer loop",
+			parameters: `[]`,
+			statements: `[{"While": {"label": "outer", "condition": {"Boolean": true}, "statements": [
+				{"While": {"condition": {"Ident": "x"}, "statements": [{"LabeledBreak": "outer"}]}}
+			]}}]`,
+		},
+		{
+			name:       "labeled break leaves inner loop",
+			parameters: `[]`,
+			statements: `[{"While": {"label": "outer", "condition": {"Boolean": true}, "statements": [
+				{"While": {"label": "inner", "condition": {"Ident": "x"}, "statements": [{"LabeledBreak": "inner"}]}}
+			]}}]`,
+			want: []string{"Account.f: while loop never terminates"},
+		},
+		{
+			name:       "statement after labeled continue",
+			parameters: `[]`,
+			statements: `[{"While": {"label": "outer", "condition": {"Ident": "x"}, "statements": [
+				{"LabeledContinue": "outer"}, "Break"
+			]}}]`,
+			want: []string{"Account.f: unreachable code after continue"},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := lintMessages(t, tc.parameters, tc.statements); !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("got %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,46 @@
+package parser
+
+import "fmt"
+
+type ModuleFormat string
+
+const (
+	// ModuleFormatScript is a plain script that attaches the collection's
+	// methods to a $$__instance variable already in scope.
+	ModuleFormatScript ModuleFormat = ""
+	// ModuleFormatESM default-exports a function taking the instance.
+	ModuleFormatESM ModuleFormat = "esm"
+	// ModuleFormatCJS assigns a function taking the instance to module.exports.
+	ModuleFormatCJS ModuleFormat = "cjs"
+	// ModuleFormatIIFE immediately attaches the methods to the global named
+	// by JSOptions.GlobalName, without leaking helpers into global scope.
+	ModuleFormatIIFE ModuleFormat = "iife"
+)
+
+type JSOptions struct {
+	ModuleFormat ModuleFormat
+	// GlobalName is the instance the IIFE format attaches methods to.
+	// Defaults to $$__instance. Ignored by other formats.
+	GlobalName string
+}
+
+// wrapModule wraps the code generated for a collection, which expects a
+// $$__instance variable in scope, in the module format opts selects.
+func wrapModule(code string, opts JSOptions) (string, error) {
+	switch opts.ModuleFormat {
+	case ModuleFormatScript:
+		return code, nil
+	case ModuleFormatESM:
+		return "export default function ($$__instance) {\n" + code + "\nreturn instance;\n}\n", nil
+	case ModuleFormatCJS:
+		return "module.exports = function ($$__instance) {\n" + code + "\nreturn instance;\n};\n", nil
+	case ModuleFormatIIFE:
+		globalName := opts.GlobalName
+		if globalName == "" {
+			globalName = "$$__instance"
+		}
+		return "(function ($$__instance) {\n" + code + "\n})(" + globalName + ");\n", nil
+	default:
+		return "", fmt.Errorf("unknown module format %q", opts.ModuleFormat)
+	}
+}
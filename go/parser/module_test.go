@@ -0,0 +1,66 @@
+package parser
+
+import (
+	"testing"
+)
+
+// generatedCode stands in for the output of the library's code generator
+const generatedCode = "const instance = $$__instance;\ninstance.f = function f () {\n};"
+
+func TestWrapModule(t *testing.T) {
+	cases := []struct {
+		name string
+		opts JSOptions
+		want string
+	}{
+		{
+			name: "script",
+			opts: JSOptions{},
+			want: generatedCode,
+		},
+		{
+			name: "esm",
+			opts: JSOptions{ModuleFormat: ModuleFormatESM},
+			want: "export default function ($$__instance) {\n" + generatedCode + "\nreturn instance;\n}\n",
+		},
+		{
+			name: "cjs",
+			opts: JSOptions{ModuleFormat: ModuleFormatCJS},
+			want: "module.exports = function ($$__instance) {\n" + generatedCode + "\nreturn instance;\n};\n",
+		},
+		{
+			name: "iife",
+			opts: JSOptions{ModuleFormat: ModuleFormatIIFE},
+			want: "(function ($$__instance) {\n" + generatedCode + "\n})($$__instance);\n",
+		},
+		{
+			name: "iife with global name",
+			opts: JSOptions{ModuleFormat: ModuleFormatIIFE, GlobalName: "window.account"},
+			want: "(function ($$__instance) {\n" + generatedCode + "\n})(window.account);\n",
+		},
+		{
+			name: "global name ignored by esm",
+			opts: JSOptions{ModuleFormat: ModuleFormatESM, GlobalName: "window.account"},
+			want: "export default function ($$__instance) {\n" + generatedCode + "\nreturn instance;\n}\n",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := wrapModule(generatedCode, tc.opts)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != tc.want {
+				t.Errorf("got %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestWrapModuleUnknownFormat(t *testing.T) {
+	_, err := wrapModule(generatedCode, JSOptions{ModuleFormat: "amd"})
+	if want := `unknown module format "amd"`; err == nil || err.Error() != want {
+		t.Errorf("got error %v, want %q", err, want)
+	}
+}
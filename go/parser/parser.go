@@ -1,18 +1,19 @@
 package parser
 
-/*
-#cgo darwin LDFLAGS: ${SRCDIR}/libpolylang.a
-#cgo linux LDFLAGS: -lpolylang
-#include "./polylang.h"
-*/
-import "C"
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"strings"
+
+	"github.com/polybase/polylang/ast"
 )
 
+// ErrCgoUnavailable is returned by every function that needs the Polylang
+// library when the package is built without cgo.
+var ErrCgoUnavailable = errors.New("polylang: parser requires cgo")
+
 type Result[T any] struct {
 	Ok  T
 	Err *Error
@@ -20,6 +21,17 @@ type Result[T any] struct {
 
 type Error struct {
 	Message string `json:"message"`
+	// Line is 1-based. Line is zero, and only zero, when the error has no
+	// source position; Column and Length are then meaningless.
+	Line int `json:"line"`
+	// Column is the 0-based byte offset of the error within Line, so zero
+	// is a valid column. Check Line to tell whether there is a position.
+	Column int `json:"column"`
+	Length int `json:"length"`
+}
+
+func (e *Error) Error() string {
+	return e.Message
 }
 
 func IsAuthError(err error) bool {
@@ -27,6 +39,14 @@ func IsAuthError(err error) bool {
 	return strings.Contains(err.Error(), "Missing public key from auth")
 }
 
+type VersionInfo struct {
+	// Version is the semantic version of the Polylang library.
+	Version string `json:"version"`
+	// ABI is incremented whenever the JSON exchanged with the library
+	// changes shape.
+	ABI int `json:"abi"`
+}
+
 type EvalInput struct {
 	Code string `json:"code"`
 }
@@ -38,27 +58,125 @@ func parseResult[T any](resultJSON string) (T, error) {
 	}
 
 	if result.Err != nil {
-		return result.Ok, errors.New(result.Err.Message)
+		return result.Ok, result.Err
 	}
 
 	return result.Ok, nil
 }
 
 func Parse(input string) (json.RawMessage, error) {
-	output := C.parse(C.CString(input))
-	return parseResult[json.RawMessage](C.GoString(output))
+	output, err := callParse(input)
+	if err != nil {
+		return nil, err
+	}
+
+	return parseResult[json.RawMessage](output)
 }
 
 func ValidateSet(collectionAST, data string) error {
-	output := C.validate_set(C.CString(collectionAST), C.CString(data))
-	if _, err := parseResult[json.RawMessage](C.GoString(output)); err != nil {
+	output, err := callValidateSet(collectionAST, data)
+	if err != nil {
+		return err
+	}
+
+	if _, err := parseResult[json.RawMessage](output); err != nil {
 		return err
 	}
 
 	return nil
 }
 
+// ValidateSetContext is like ValidateSet but returns ctx.Err() as soon as
+// ctx is done. The library call itself cannot be interrupted, so it keeps
+// running in the background until it finishes and its result is discarded.
+func ValidateSetContext(ctx context.Context, collectionAST, data string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	done := make(chan error, 1)
+	go func() {
+		done <- ValidateSet(collectionAST, data)
+	}()
+
+	select {
+	case err := <-done:
+		return err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
 func GenerateJSCollection(collectionAST string) (EvalInput, error) {
-	output := C.generate_js_collection(C.CString(collectionAST))
-	return parseResult[EvalInput](C.GoString(output))
+	return GenerateJSCollectionWithOptions(collectionAST, JSOptions{})
+}
+
+func GenerateJSCollectionWithOptions(collectionAST string, opts JSOptions) (EvalInput, error) {
+	output, err := callGenerateJSCollection(collectionAST)
+	if err != nil {
+		return EvalInput{}, err
+	}
+
+	input, err := parseResult[EvalInput](output)
+	if err != nil {
+		return input, err
+	}
+
+	if input.Code, err = wrapModule(input.Code, opts); err != nil {
+		return EvalInput{}, err
+	}
+
+	return input, nil
+}
+
+// MethodSignature describes a method defined by generated collection code.
+type MethodSignature struct {
+	Name       string
+	Parameters []ast.Parameter
+	// ReturnType is nil for methods that declare no return type.
+	ReturnType *ast.Type
+}
+
+type JSCollection struct {
+	Code    string
+	Methods []MethodSignature
+}
+
+// GenerateJSCollectionWithMethods is like GenerateJSCollectionWithOptions
+// but also lists the methods the generated code defines, in declaration
+// order, so callers can generate bindings for them.
+func GenerateJSCollectionWithMethods(collectionAST string, opts JSOptions) (JSCollection, error) {
+	var collection ast.Collection
+	if err := json.Unmarshal([]byte(collectionAST), &collection); err != nil {
+		return JSCollection{}, fmt.Errorf("failed to parse collection AST: %w", err)
+	}
+
+	input, err := GenerateJSCollectionWithOptions(collectionAST, opts)
+	if err != nil {
+		return JSCollection{}, err
+	}
+
+	result := JSCollection{Code: input.Code}
+	for _, item := range collection.Items {
+		if item.Function != nil {
+			result.Methods = append(result.Methods, MethodSignature{
+				Name:       item.Function.Name,
+				Parameters: item.Function.Parameters,
+				ReturnType: item.Function.ReturnType,
+			})
+		}
+	}
+
+	return result, nil
+}
+
+// Version reports the version of the linked Polylang library. Callers that
+// cache parse or codegen output should include it in their cache key.
+func Version() (VersionInfo, error) {
+	output, err := callVersion()
+	if err != nil {
+		return VersionInfo{}, err
+	}
+
+	return parseResult[VersionInfo](output)
 }
@@ -0,0 +1,84 @@
+//go:build cgo
+
+package parser
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseErrorPosition(t *testing.T) {
+	_, err := Parse("collection X { name string }")
+
+	var parseErr *Error
+	if !errors.As(err, &parseErr) {
+		t.Fatalf("expected *Error, got %v", err)
+	}
+	// The missing colon is reported at string, on the first line
+	if parseErr.Line != 1 || parseErr.Column <= 0 {
+		t.Errorf("got line %d column %d, want a position on line 1 past column 0", parseErr.Line, parseErr.Column)
+	}
+}
+
+const counterCollection = `{"name": "Counter", "items": [
+	{"Field": {"name": "id", "type_": {"tag": "String"}, "required": true}},
+	{"Field": {"name": "value", "type_": {"tag": "Number"}, "required": true}},
+	{"Function": {
+		"name": "add",
+		"parameters": [
+			{"name": "amount", "type_": {"tag": "Number"}, "required": true},
+			{"name": "note", "type_": {"tag": "String"}, "required": false}
+		],
+		"return_type": null,
+		"statements": [],
+		"statements_code": "this.value += amount;"
+	}},
+	{"Function": {
+		"name": "current",
+		"parameters": [],
+		"return_type": {"tag": "Number"},
+		"statements": [],
+		"statements_code": "return this.value;"
+	}}
+]}`
+
+func TestGenerateJSCollectionWithMethods(t *testing.T) {
+	collection, err := GenerateJSCollectionWithMethods(counterCollection, JSOptions{ModuleFormat: ModuleFormatESM})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !strings.HasPrefix(collection.Code, "export default function") {
+		t.Errorf("code is not an ES module:\n%s", collection.Code)
+	}
+
+	var names []string
+	for _, method := range collection.Methods {
+		names = append(names, method.Name)
+		if !strings.Contains(collection.Code, "instance."+method.Name+" = ") {
+			t.Errorf("code does not define %s", method.Name)
+		}
+	}
+	if want := []string{"add", "current"}; !reflect.DeepEqual(names, want) {
+		t.Fatalf("got methods %v, want %v", names, want)
+	}
+
+	add, current := collection.Methods[0], collection.Methods[1]
+	if len(add.Parameters) != 2 ||
+		add.Parameters[0].Name != "amount" || !add.Parameters[0].Type.IsNumber() || !add.Parameters[0].Required ||
+		add.Parameters[1].Name != "note" || !add.Parameters[1].Type.IsString() || add.Parameters[1].Required {
+		t.Errorf("add: got parameters %+v", add.Parameters)
+	}
+	if add.ReturnType != nil {
+		t.Errorf("add: got return type %+v, want none", add.ReturnType)
+	}
+
+	if len(current.Parameters) != 0 {
+		t.Errorf("current: got parameters %+v, want none", current.Parameters)
+	}
+	if current.ReturnType == nil || !current.ReturnType.IsNumber() {
+		t.Errorf("current: got return type %+v, want number", current.ReturnType)
+	}
+}
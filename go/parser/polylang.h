@@ -3,3 +3,5 @@
 char *parse(const char *input);
 char *validate_set(const char *ast_json, const char *data_json);
 char *generate_js_collection(const char *collection_ast_json);
+char *tokenize(const char *input);
+char *version(void);
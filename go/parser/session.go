@@ -0,0 +1,62 @@
+package parser
+
+import (
+	"encoding/json"
+	"errors"
+)
+
+// Session keeps the result of the last parse for an editor buffer. Update
+// currently reuses the previous result only when the source is unchanged;
+// keeping lexer and parser state here is what will let it re-parse only the
+// edited region later without changing callers.
+//
+// A Session is not safe for concurrent use; it is meant to be driven by a
+// single editor goroutine.
+type Session struct {
+	source      string
+	parsed      bool
+	ast         json.RawMessage
+	diagnostics []Diagnostic
+}
+
+func NewSession() *Session {
+	return &Session{}
+}
+
+// Update parses newSource and returns its syntax errors as diagnostics.
+// The returned error is reserved for failures unrelated to the source, such
+// as the library being unavailable.
+func (s *Session) Update(newSource string) ([]Diagnostic, error) {
+	if s.parsed && newSource == s.source {
+		return s.diagnostics, nil
+	}
+
+	programAST, err := Parse(newSource)
+
+	var parseErr *Error
+	switch {
+	case err == nil:
+		s.ast = programAST
+		s.diagnostics = nil
+	case errors.As(err, &parseErr):
+		s.ast = nil
+		s.diagnostics = []Diagnostic{{
+			Line:     parseErr.Line,
+			Column:   parseErr.Column,
+			Severity: SeverityError,
+			Message:  parseErr.Message,
+		}}
+	default:
+		return nil, err
+	}
+
+	s.source = newSource
+	s.parsed = true
+	return s.diagnostics, nil
+}
+
+// AST returns the program AST from the last successful Update, or nil if
+// the current source does not parse.
+func (s *Session) AST() json.RawMessage {
+	return s.ast
+}
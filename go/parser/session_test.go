@@ -0,0 +1,48 @@
+//go:build cgo
+
+package parser
+
+import (
+	"strings"
+	"testing"
+)
+
+const benchmarkSource = `
+	collection Account {
+		id: string;
+		balance: number;
+
+		function transfer(to: Account, amount: number) {
+			if (this.balance < amount) {
+				throw error('insufficient balance');
+			}
+			this.balance -= amount;
+			to.balance += amount;
+		}
+	}
+`
+
+// BenchmarkSessionUpdate alternates between two sources, as an editor does
+// while the user types, so every Update re-parses instead of returning the
+// diagnostics it cached for unchanged source.
+func BenchmarkSessionUpdate(b *testing.B) {
+	sources := [2]string{
+		benchmarkSource,
+		strings.Replace(benchmarkSource, "balance: number;", "balance: number;\n\t\tfrozen: boolean;", 1),
+	}
+
+	session := NewSession()
+	for i := 0; i < b.N; i++ {
+		if _, err := session.Update(sources[i%2]); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkParse(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		if _, err := Parse(benchmarkSource); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
@@ -0,0 +1,33 @@
+package parser
+
+type TokenKind string
+
+const (
+	TokenKeyword     TokenKind = "keyword"
+	TokenType        TokenKind = "type"
+	TokenIdentifier  TokenKind = "identifier"
+	TokenNumber      TokenKind = "number"
+	TokenString      TokenKind = "string"
+	TokenComment     TokenKind = "comment"
+	TokenPunctuation TokenKind = "punctuation"
+	// TokenInvalid covers text the lexer could not make sense of.
+	TokenInvalid TokenKind = "invalid"
+)
+
+type Token struct {
+	Kind TokenKind `json:"kind"`
+	// Start and End are byte offsets into the tokenized input.
+	Start int `json:"start"`
+	End   int `json:"end"`
+}
+
+// Tokenize classifies the tokens of input for syntax highlighting. It is
+// cheaper than Parse and succeeds on incomplete or invalid source.
+func Tokenize(input string) ([]Token, error) {
+	output, err := callTokenize(input)
+	if err != nil {
+		return nil, err
+	}
+
+	return parseResult[[]Token](output)
+}
@@ -0,0 +1,129 @@
+package parser
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/polybase/polylang/ast"
+)
+
+// UnknownFieldsError lists the paths of fields in a record that its
+// collection does not declare.
+type UnknownFieldsError struct {
+	Paths []string
+}
+
+func (e *UnknownFieldsError) Error() string {
+	return "unknown fields: " + strings.Join(e.Paths, ", ")
+}
+
+// ValidateSetStrict is like ValidateSet but first reports every field in
+// data that the collection does not declare, at any depth, as an
+// *UnknownFieldsError. ValidateSet rejects unknown fields too, but stops at
+// the first problem it finds.
+func ValidateSetStrict(collectionAST, data string) error {
+	var collection ast.Collection
+	if err := json.Unmarshal([]byte(collectionAST), &collection); err != nil {
+		return fmt.Errorf("failed to parse collection AST: %w", err)
+	}
+
+	var record map[string]any
+	if err := json.Unmarshal([]byte(data), &record); err != nil {
+		return fmt.Errorf("failed to parse data: %w", err)
+	}
+
+	var paths []string
+	unknownFields("", record, collection.Fields(), &paths)
+	if len(paths) > 0 {
+		sort.Strings(paths)
+		return &UnknownFieldsError{Paths: paths}
+	}
+
+	return ValidateSet(collectionAST, data)
+}
+
+func unknownFields(path string, record map[string]any, fields []ast.Field, paths *[]string) {
+	for key, value := range record {
+		field := ast.FindField(fields, key)
+		if field == nil {
+			*paths = append(*paths, ast.JoinPath(path, key))
+			continue
+		}
+
+		unknownFieldsIn(ast.JoinPath(path, key), value, &field.Type, paths)
+	}
+}
+
+// unknownFieldsIn descends into value, following arrays and maps to the
+// objects they contain. Values that do not match t are left for
+// ValidateSet to report.
+func unknownFieldsIn(path string, value any, t *ast.Type, paths *[]string) {
+	switch {
+	case t.IsObject():
+		fields, err := t.Object()
+		if object, ok := value.(map[string]any); ok && err == nil {
+			unknownFields(path, object, fields, paths)
+		}
+	case t.IsArray():
+		elem, err := t.Array()
+		if items, ok := value.([]any); ok && err == nil {
+			for i, item := range items {
+				unknownFieldsIn(fmt.Sprintf("%s[%d]", path, i), item, elem, paths)
+			}
+		}
+	case t.IsMap():
+		_, vt, err := t.Map()
+		if entries, ok := value.(map[string]any); ok && err == nil {
+			for key, entry := range entries {
+				unknownFieldsIn(ast.JoinPath(path, key), entry, vt, paths)
+			}
+		}
+	}
+}
+
+// ValidateUpdate checks a partial record, such as the body of a PATCH
+// request, against the fields of collectionAST. Only the fields present in
+// patch are checked, so required fields may be omitted. Unknown fields and
+// id, which identifies the record and cannot change, are rejected. Null is
+// rejected too, as ValidateSet rejects it: records have no null values, so
+// an optional field is cleared by writing the record without it.
+func ValidateUpdate(collectionAST, patch string) []FieldError {
+	var collection ast.Collection
+	if err := json.Unmarshal([]byte(collectionAST), &collection); err != nil {
+		return []FieldError{{Message: fmt.Sprintf("failed to parse collection AST: %s", err)}}
+	}
+
+	var values map[string]any
+	if err := json.Unmarshal([]byte(patch), &values); err != nil {
+		return []FieldError{{Message: fmt.Sprintf("patch must be a JSON object: %s", err)}}
+	}
+
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	fields := collection.Fields()
+
+	var errs []FieldError
+	for _, key := range keys {
+		field := ast.FindField(fields, key)
+		switch {
+		case field == nil:
+			errs = append(errs, FieldError{Field: key, Message: "unknown field"})
+		case key == "id":
+			errs = append(errs, FieldError{Field: key, Message: "cannot be updated"})
+		case values[key] == nil:
+			errs = append(errs, FieldError{Field: key, Message: "cannot be null"})
+		default:
+			if err := field.Type.ValidateValue(values[key]); err != nil {
+				errs = append(errs, FieldError{Field: key, Message: err.Error()})
+			}
+		}
+	}
+
+	return errs
+}
@@ -0,0 +1,128 @@
+package parser
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+)
+
+const accountCollection = `{"name": "Account", "items": [
+	{"Field": {"name": "id", "type_": {"tag": "String"}, "required": true}},
+	{"Field": {"name": "balance", "type_": {"tag": "Number"}, "required": true}},
+	{"Field": {"name": "memo", "type_": {"tag": "String"}, "required": false}},
+	{"Field": {"name": "address", "type_": {"tag": "Object", "content": [
+		{"name": "city", "type_": {"tag": "String"}, "required": true}
+	]}, "required": false}},
+	{"Function": {
+		"name": "transfer",
+		"parameters": [
+			{"name": "to", "type_": {"tag": "ForeignRecord", "content": {"collection": "Account"}}, "required": true},
+			{"name": "amount", "type_": {"tag": "Number"}, "required": true},
+			{"name": "note", "type_": {"tag": "String"}, "required": false, "default": "none"}
+		],
+		"return_type": null,
+		"statements": [],
+		"statements_code": ""
+	}}
+]}`
+
+func TestValidateSetStrictUnknownField(t *testing.T) {
+	err := ValidateSetStrict(accountCollection, `{"id": "1", "balance": 1, "address": {"city": "x", "cty": "y"}}`)
+
+	var unknown *UnknownFieldsError
+	if !errors.As(err, &unknown) {
+		t.Fatalf("expected *UnknownFieldsError, got %v", err)
+	}
+	if want := []string{"address.cty"}; !reflect.DeepEqual(unknown.Paths, want) {
+		t.Errorf("got %v, want %v", unknown.Paths, want)
+	}
+}
+
+func TestValidateSetStrictKnownFields(t *testing.T) {
+	err := ValidateSetStrict(accountCollection, `{"id": "1", "balance": 1, "address": {"city": "x"}}`)
+
+	// Without unknown fields, validation falls through to ValidateSet,
+	// which needs the library
+	if err != nil && !errors.Is(err, ErrCgoUnavailable) {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestValidateUpdate(t *testing.T) {
+	cases := []struct {
+		name  string
+		patch string
+		want  []FieldError
+	}{
+		{
+			name:  "valid partial patch",
+			patch: `{"balance": 5, "memo": "rent"}`,
+			want:  nil,
+		},
+		{
+			name:  "id cannot change",
+			patch: `{"id": "2"}`,
+			want:  []FieldError{{Field: "id", Message: "cannot be updated"}},
+		},
+		{
+			name:  "bad type",
+			patch: `{"balance": "5"}`,
+			want:  []FieldError{{Field: "balance", Message: "expected number"}},
+		},
+		{
+			name:  "unknown field",
+			patch: `{"balanc": 5}`,
+			want:  []FieldError{{Field: "balanc", Message: "unknown field"}},
+		},
+		{
+			name:  "null required field",
+			patch: `{"balance": null}`,
+			want:  []FieldError{{Field: "balance", Message: "cannot be null"}},
+		},
+		{
+			name:  "null optional field",
+			patch: `{"memo": null}`,
+			want:  []FieldError{{Field: "memo", Message: "cannot be null"}},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := ValidateUpdate(accountCollection, tc.patch); !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("got %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestValidateSetContextDone(t *testing.T) {
+	cancelled, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	expired, cancel := context.WithDeadline(context.Background(), time.Now().Add(-time.Second))
+	defer cancel()
+
+	cases := []struct {
+		name string
+		ctx  context.Context
+		want error
+	}{
+		{name: "cancelled", ctx: cancelled, want: context.Canceled},
+		{name: "deadline exceeded", ctx: expired, want: context.DeadlineExceeded},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			start := time.Now()
+			err := ValidateSetContext(tc.ctx, accountCollection, `{"id": "1", "balance": 1}`)
+			if !errors.Is(err, tc.want) {
+				t.Errorf("got %v, want %v", err, tc.want)
+			}
+			if elapsed := time.Since(start); elapsed > 50*time.Millisecond {
+				t.Errorf("returned after %s", elapsed)
+			}
+		})
+	}
+}
@@ -29,14 +29,158 @@ pub struct Field {
     pub name: String,
     pub type_: Type,
     pub required: bool,
+    /// Value stored when a record omits the field. Fields with a default
+    /// are never required.
+    #[serde(default, skip_serializing_if = "Option::is_none")]
+    pub default: Option<serde_json::Value>,
+    #[serde(default, skip_serializing_if = "Vec::is_empty")]
+    pub decorators: Vec<FieldDecorator>,
 }
 
-#[derive(Debug, Serialize, Deserialize)]
+#[derive(Debug, Clone, PartialEq, Serialize, Deserialize)]
 pub struct FieldDecorator {
     pub name: String,
     pub arguments: Vec<Primitive>,
 }
 
+impl FieldDecorator {
+    /// Checks that the decorator is one Polylang knows, that it has the
+    /// arguments it takes and that it applies to a field of type type_.
+    pub fn check(&self, type_: &Type) -> Result<(), String> {
+        match (self.name.as_str(), self.arguments.as_slice()) {
+            ("regex", [Primitive::Regex(pattern)]) => {
+                if *type_ != Type::String {
+                    return Err("@regex only applies to string fields".to_string());
+                }
+
+                check_regex(pattern)
+            }
+            ("regex", _) => Err("@regex takes a single string argument".to_string()),
+            ("min" | "max", [Primitive::Number(_)]) => {
+                if *type_ != Type::Number {
+                    return Err(format!("@{} only applies to number fields", self.name));
+                }
+
+                Ok(())
+            }
+            ("minLength" | "maxLength", [Primitive::Number(n)]) => {
+                if *type_ != Type::String {
+                    return Err(format!("@{} only applies to string fields", self.name));
+                }
+                if *n < 0.0 || n.fract() != 0.0 {
+                    return Err(format!("@{} takes a non-negative integer", self.name));
+                }
+
+                Ok(())
+            }
+            ("min" | "max" | "minLength" | "maxLength", _) => {
+                Err(format!("@{} takes a single number argument", self.name))
+            }
+            _ => Err(format!("Unknown decorator \"@{}\"", self.name)),
+        }
+    }
+}
+
+fn check_regex(pattern: &str) -> Result<(), String> {
+    if let Err(err) = regex::Regex::new(pattern) {
+        // The last line of a syntax error names the problem; the lines
+        // before it point at the pattern, which the parse error already does
+        let err = err.to_string();
+        let reason = err.lines().last().unwrap_or_default();
+        return Err(format!(
+            "Invalid regex \"{}\": {}",
+            pattern,
+            reason.trim_start_matches("error: ")
+        ));
+    }
+
+    if nests_unbounded_quantifiers(pattern) {
+        return Err(format!(
+            "Regex \"{}\" nests unbounded quantifiers, which can backtrack catastrophically",
+            pattern
+        ));
+    }
+
+    Ok(())
+}
+
+/// Reports whether pattern applies an unbounded quantifier to a group that
+/// contains one, as in (a+)+. Such patterns backtrack catastrophically in
+/// the JavaScript engines that also run them. pattern must be a valid regex.
+fn nests_unbounded_quantifiers(pattern: &str) -> bool {
+    // Whether each open group, and the pattern itself, contains an
+    // unbounded quantifier
+    let mut groups = vec![false];
+    // Whether the atom before the current character is a group that does
+    let mut after_unbounded_group = false;
+    let mut chars = pattern.chars().peekable();
+
+    while let Some(c) = chars.next() {
+        let unbounded = match c {
+            '\\' => {
+                chars.next();
+                false
+            }
+            '[' => {
+                if chars.peek() == Some(&'^') {
+                    chars.next();
+                }
+                // A ] right at the start of a class is a literal
+                if chars.peek() == Some(&']') {
+                    chars.next();
+                }
+                while let Some(c) = chars.next() {
+                    match c {
+                        '\\' => {
+                            chars.next();
+                        }
+                        ']' => break,
+                        _ => {}
+                    }
+                }
+                false
+            }
+            '(' => {
+                groups.push(false);
+                after_unbounded_group = false;
+                continue;
+            }
+            ')' => {
+                let inner = groups.pop().unwrap_or_default();
+                if let Some(outer) = groups.last_mut() {
+                    *outer |= inner;
+                }
+                after_unbounded_group = inner;
+                continue;
+            }
+            '*' | '+' => true,
+            '{' => {
+                let mut bounds = String::new();
+                for c in chars.by_ref() {
+                    if c == '}' {
+                        break;
+                    }
+                    bounds.push(c);
+                }
+                bounds.ends_with(',')
+            }
+            _ => false,
+        };
+
+        if unbounded {
+            if after_unbounded_group {
+                return true;
+            }
+            if let Some(group) = groups.last_mut() {
+                *group = true;
+            }
+        }
+        after_unbounded_group = false;
+    }
+
+    false
+}
+
 #[derive(Debug, Clone, PartialEq, Serialize, Deserialize)]
 #[serde(tag = "tag", content = "content")]
 pub enum Type {
@@ -66,6 +210,10 @@ pub struct Parameter {
     pub name: String,
     pub type_: ParameterType,
     pub required: bool,
+    /// Value used when the argument is omitted. Parameters with a default
+    /// are never required.
+    #[serde(default, skip_serializing_if = "Option::is_none")]
+    pub default: Option<serde_json::Value>,
 }
 
 #[derive(Debug, Serialize, Deserialize)]
@@ -77,6 +225,55 @@ pub struct Function {
     pub statements_code: String,
 }
 
+impl Function {
+    /// Checks that every labeled break and continue refers to a loop that
+    /// encloses it, and that no loop reuses the label of a loop around it.
+    pub fn check_labels(&self) -> Result<(), String> {
+        check_labels(&self.statements, &mut vec![])
+    }
+}
+
+fn check_labels<'a>(statements: &'a [Statement], labels: &mut Vec<&'a str>) -> Result<(), String> {
+    for statement in statements {
+        match statement {
+            Statement::LabeledBreak(label) | Statement::LabeledContinue(label) => {
+                if !labels.contains(&label.as_str()) {
+                    return Err(format!("Undefined label \"{}\"", label));
+                }
+            }
+            Statement::If(If {
+                then_statements,
+                else_statements,
+                ..
+            }) => {
+                check_labels(then_statements, labels)?;
+                check_labels(else_statements, labels)?;
+            }
+            Statement::While(While {
+                label, statements, ..
+            })
+            | Statement::For(For {
+                label, statements, ..
+            }) => match label {
+                Some(label) => {
+                    if labels.contains(&label.as_str()) {
+                        return Err(format!("Duplicate label \"{}\"", label));
+                    }
+
+                    labels.push(label);
+                    let result = check_labels(statements, labels);
+                    labels.pop();
+                    result?;
+                }
+                None => check_labels(statements, labels)?,
+            },
+            _ => {}
+        }
+    }
+
+    Ok(())
+}
+
 #[derive(Debug, Serialize, Deserialize)]
 pub struct Index {
     pub fields: Vec<IndexField>,
@@ -97,6 +294,11 @@ pub enum Order {
 #[derive(Debug, Serialize, Deserialize)]
 pub enum Statement {
     Break,
+    Continue,
+    /// break with a label, leaving the enclosing loop of that name
+    LabeledBreak(String),
+    /// continue with a label, continuing the enclosing loop of that name
+    LabeledContinue(String),
     If(If),
     While(While),
     For(For),
@@ -157,12 +359,16 @@ pub struct If {
 
 #[derive(Debug, Serialize, Deserialize)]
 pub struct While {
+    #[serde(default, skip_serializing_if = "Option::is_none")]
+    pub label: Option<String>,
     pub condition: Expression,
     pub statements: Vec<Statement>,
 }
 
 #[derive(Debug, Serialize, Deserialize)]
 pub struct For {
+    #[serde(default, skip_serializing_if = "Option::is_none")]
+    pub label: Option<String>,
     pub initial_statement: ForInitialStatement,
     pub condition: Expression,
     pub post_statement: Expression,
@@ -175,7 +381,7 @@ pub enum ForInitialStatement {
     Expression(Expression),
 }
 
-#[derive(Debug, PartialEq, Serialize, Deserialize)]
+#[derive(Debug, Clone, PartialEq, Serialize, Deserialize)]
 pub enum Primitive {
     Number(f64),
     String(String),
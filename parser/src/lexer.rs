@@ -1,3 +1,4 @@
+use serde::Serialize;
 use std::f32::consts::E;
 
 pub type Spanned<Tok, Loc, Error> = Result<(Loc, Tok, Loc), Error>;
@@ -18,6 +19,7 @@ pub enum Tok<'input> {
     Record,
     Let,
     Break,
+    Continue,
     Return,
     Throw,
     If,
@@ -80,6 +82,7 @@ impl std::fmt::Display for Tok<'_> {
             Tok::Record => write!(f, "record"),
             Tok::Let => write!(f, "let"),
             Tok::Break => write!(f, "break"),
+            Tok::Continue => write!(f, "continue"),
             Tok::Return => write!(f, "return"),
             Tok::Throw => write!(f, "throw"),
             Tok::If => write!(f, "if"),
@@ -180,6 +183,18 @@ impl std::fmt::Display for LexicalError {
 
 impl std::error::Error for LexicalError {}
 
+impl LexicalError {
+    pub fn span(&self) -> (usize, usize) {
+        match self {
+            LexicalError::NumberParseError { start, end }
+            | LexicalError::InvalidToken { start, end }
+            | LexicalError::UnterminatedComment { start, end }
+            | LexicalError::UnterminatedString { start, end }
+            | LexicalError::UserError { start, end, .. } => (*start, *end),
+        }
+    }
+}
+
 const KEYWORDS: &[(Tok, &str)] = &[
     (Tok::Desc, "desc"),
     (Tok::Asc, "asc"),
@@ -192,6 +207,7 @@ const KEYWORDS: &[(Tok, &str)] = &[
     (Tok::Record, "record"),
     (Tok::Let, "let"),
     (Tok::Break, "break"),
+    (Tok::Continue, "continue"),
     (Tok::Return, "return"),
     (Tok::Throw, "throw"),
     (Tok::If, "if"),
@@ -353,6 +369,14 @@ impl<'input> Lexer<'input> {
             return None;
         }
 
+        if c == '0' {
+            match self.peek_char_nth(1) {
+                Some((_, 'x' | 'X')) => return Some(self.lex_radix_number(start, 16)),
+                Some((_, 'b' | 'B')) => return Some(self.lex_radix_number(start, 2)),
+                _ => {}
+            }
+        }
+
         let mut end = start;
         let mut number = String::new();
         while let Some((i, c)) = self.peek_char() {
@@ -374,6 +398,31 @@ impl<'input> Lexer<'input> {
             .ok()
     }
 
+    /// Parses hexadecimal (`0xFF`) and binary (`0b1010`) integer literals.
+    /// Numbers are f64s, so literals above 2^53 - 1 are rejected rather than
+    /// silently rounded.
+    fn lex_radix_number(&mut self, start: usize, radix: u32) -> LexerItem<'input> {
+        const MAX_SAFE_INTEGER: u64 = (1 << 53) - 1;
+
+        // Skip the 0x or 0b prefix
+        self.next_char();
+        self.next_char();
+
+        let digits_start = self.position;
+        while let Some((_, c)) = self.peek_char() {
+            if !c.is_ascii_alphanumeric() {
+                break;
+            }
+            self.next_char();
+        }
+        let end = self.position;
+
+        match u64::from_str_radix(&self.input[digits_start..end], radix) {
+            Ok(n) if n <= MAX_SAFE_INTEGER => Ok((start, Tok::NumberLiteral(n as f64), end)),
+            _ => Err(LexicalError::NumberParseError { start, end }),
+        }
+    }
+
     /// parses 'hello' as Tok::String("'hello'")
     fn lex_string(&mut self) -> Option<LexerItem<'input>> {
         let (start, c) = self.peek_char()?;
@@ -644,6 +693,124 @@ impl<'input> Iterator for Lexer<'input> {
     }
 }
 
+#[derive(Debug, Clone, Copy, PartialEq, Serialize)]
+#[serde(rename_all = "lowercase")]
+pub enum TokenKind {
+    Keyword,
+    Type,
+    Identifier,
+    Number,
+    String,
+    Comment,
+    Punctuation,
+    Invalid,
+}
+
+#[derive(Debug, PartialEq, Serialize)]
+pub struct Token {
+    pub kind: TokenKind,
+    pub start: usize,
+    pub end: usize,
+}
+
+/// Splits input into classified tokens for syntax highlighting. Unlike the
+/// parser, this never fails: text the lexer rejects is reported as an
+/// `Invalid` token and lexing resumes after it.
+pub fn tokenize(input: &str) -> Vec<Token> {
+    let mut tokens = Vec::new();
+    let mut offset = 0;
+
+    loop {
+        let mut last_end = offset;
+        let mut error = None;
+        for item in Lexer::new(&input[offset..]) {
+            match item {
+                Ok((start, tok, end)) => {
+                    push_comments(input, last_end, offset + start, &mut tokens);
+                    tokens.push(Token {
+                        kind: token_kind(&tok),
+                        start: offset + start,
+                        end: offset + end,
+                    });
+                    last_end = offset + end;
+                }
+                Err(err) => {
+                    error = Some(err);
+                    break;
+                }
+            }
+        }
+
+        let Some(error) = error else {
+            push_comments(input, last_end, input.len(), &mut tokens);
+            return tokens;
+        };
+
+        let (start, end) = error.span();
+        let start = offset + start;
+        // InvalidToken errors have an empty span, cover the offending character
+        let char_len = input[start..].chars().next().map_or(0, |c| c.len_utf8());
+        let end = (offset + end).max(start + char_len).min(input.len());
+
+        push_comments(input, last_end, start, &mut tokens);
+        tokens.push(Token {
+            kind: TokenKind::Invalid,
+            start,
+            end,
+        });
+        offset = end;
+    }
+}
+
+/// Reports the comments in input[from..to], which the lexer skips over.
+fn push_comments(input: &str, from: usize, to: usize, tokens: &mut Vec<Token>) {
+    let mut i = from;
+    while i < to {
+        let rest = &input[i..to];
+        let end = if rest.starts_with("//") {
+            rest.find('\n').map_or(to, |n| i + n)
+        } else if rest.starts_with("/*") {
+            rest.find("*/").map_or(to, |n| i + n + 2)
+        } else {
+            i += rest.chars().next().map_or(1, |c| c.len_utf8());
+            continue;
+        };
+
+        tokens.push(Token {
+            kind: TokenKind::Comment,
+            start: i,
+            end,
+        });
+        i = end;
+    }
+}
+
+fn token_kind(tok: &Tok) -> TokenKind {
+    match tok {
+        Tok::NumberLiteral(_) => TokenKind::Number,
+        Tok::StringLiteral(_) => TokenKind::String,
+        Tok::Identifier(_) => TokenKind::Identifier,
+        Tok::String | Tok::Number | Tok::Boolean | Tok::Map | Tok::Record => TokenKind::Type,
+        Tok::Desc
+        | Tok::Asc
+        | Tok::True
+        | Tok::False
+        | Tok::Let
+        | Tok::Break
+        | Tok::Continue
+        | Tok::Return
+        | Tok::Throw
+        | Tok::If
+        | Tok::Else
+        | Tok::While
+        | Tok::For
+        | Tok::Function
+        | Tok::Index
+        | Tok::Collection => TokenKind::Keyword,
+        _ => TokenKind::Punctuation,
+    }
+}
+
 #[cfg(test)]
 mod tests {
     use super::*;
@@ -680,6 +847,36 @@ mod tests {
         assert_eq!(lexer.next(), None);
     }
 
+    #[test]
+    fn test_lex_radix_number() {
+        let mut lexer = Lexer::new("0xFF 0b1010 0x1fffffffffffff");
+        assert_eq!(lexer.next(), Some(Ok((0, Tok::NumberLiteral(255.0), 4))));
+        assert_eq!(lexer.next(), Some(Ok((5, Tok::NumberLiteral(10.0), 11))));
+        assert_eq!(
+            lexer.next(),
+            Some(Ok((12, Tok::NumberLiteral(9007199254740991.0), 28)))
+        );
+        assert_eq!(lexer.next(), None);
+    }
+
+    #[test]
+    fn test_lex_radix_number_overflow() {
+        let mut lexer = Lexer::new("0x20000000000000");
+        assert_eq!(
+            lexer.next(),
+            Some(Err(LexicalError::NumberParseError { start: 0, end: 16 }))
+        );
+    }
+
+    #[test]
+    fn test_lex_radix_number_invalid_digit() {
+        let mut lexer = Lexer::new("0b102");
+        assert_eq!(
+            lexer.next(),
+            Some(Err(LexicalError::NumberParseError { start: 0, end: 5 }))
+        );
+    }
+
     #[test]
     fn test_lex_number_error() {
         let mut lexer = Lexer::new("123.456.789");
@@ -844,4 +1041,47 @@ mod tests {
             Some(Err(LexicalError::UnterminatedComment { start: 0, end: 10 }))
         );
     }
+
+    #[test]
+    fn test_tokenize() {
+        let input = "collection Test { // names\n name: string; }";
+        let kinds = tokenize(input)
+            .into_iter()
+            .map(|t| (t.kind, &input[t.start..t.end]))
+            .collect::<Vec<_>>();
+
+        assert_eq!(
+            kinds,
+            vec![
+                (TokenKind::Keyword, "collection"),
+                (TokenKind::Identifier, "Test"),
+                (TokenKind::Punctuation, "{"),
+                (TokenKind::Comment, "// names"),
+                (TokenKind::Identifier, "name"),
+                (TokenKind::Punctuation, ":"),
+                (TokenKind::Type, "string"),
+                (TokenKind::Punctuation, ";"),
+                (TokenKind::Punctuation, "}"),
+            ]
+        );
+    }
+
+    #[test]
+    fn test_tokenize_invalid() {
+        let input = "name: ą 'unterminated";
+        let kinds = tokenize(input)
+            .into_iter()
+            .map(|t| (t.kind, &input[t.start..t.end]))
+            .collect::<Vec<_>>();
+
+        assert_eq!(
+            kinds,
+            vec![
+                (TokenKind::Identifier, "name"),
+                (TokenKind::Punctuation, ":"),
+                (TokenKind::Invalid, "ą"),
+                (TokenKind::Invalid, "'unterminated"),
+            ]
+        );
+    }
 }
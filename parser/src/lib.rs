@@ -3,7 +3,7 @@ mod lexer;
 
 use lalrpop_util::lalrpop_mod;
 pub use lalrpop_util::ParseError;
-pub use lexer::LexicalError;
+pub use lexer::{tokenize, LexicalError, Token, TokenKind};
 
 lalrpop_mod!(polylang);
 
@@ -34,6 +34,18 @@ pub fn generate_js_collection(collection_ast_json: &str) -> String {
     crate::generate_js_collection_out_json(collection_ast_json)
 }
 
+#[cfg(target_arch = "wasm32")]
+#[wasm_bindgen]
+pub fn tokenize(input: &str) -> String {
+    crate::tokenize_out_json(input)
+}
+
+#[cfg(target_arch = "wasm32")]
+#[wasm_bindgen]
+pub fn version() -> String {
+    crate::version_out_json()
+}
+
 #[cfg(not(target_arch = "wasm32"))]
 #[cfg(feature = "parser")]
 #[no_mangle]
@@ -70,3 +82,22 @@ pub extern "C" fn generate_js_collection(collection_ast_json: *const c_char) ->
     let output = std::ffi::CString::new(output).unwrap();
     output.into_raw()
 }
+
+#[cfg(not(target_arch = "wasm32"))]
+#[no_mangle]
+pub extern "C" fn tokenize(input: *const c_char) -> *mut c_char {
+    let input = unsafe { std::ffi::CStr::from_ptr(input) };
+    let input = input.to_str().unwrap();
+
+    let output = crate::tokenize_out_json(input);
+    let output = std::ffi::CString::new(output).unwrap();
+    output.into_raw()
+}
+
+#[cfg(not(target_arch = "wasm32"))]
+#[no_mangle]
+pub extern "C" fn version() -> *mut c_char {
+    let output = crate::version_out_json();
+    let output = std::ffi::CString::new(output).unwrap();
+    output.into_raw()
+}
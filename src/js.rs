@@ -44,7 +44,10 @@ fn generate_js_function(func_ast: &ast::Function) -> JSFunc {
     let parameters = func_ast
         .parameters
         .iter()
-        .map(|p| format!("{}", p.name))
+        .map(|p| match &p.default {
+            Some(default) => format!("{} = {}", p.name, default),
+            None => format!("{}", p.name),
+        })
         .collect::<Vec<String>>()
         .join(", ");
 
@@ -70,11 +73,13 @@ mod tests {
                     name: "a".to_string(),
                     type_: ast::ParameterType::String,
                     required: true,
+                    default: None,
                 },
                 ast::Parameter {
                     name: "b".to_string(),
                     type_: ast::ParameterType::Number,
                     required: false,
+                    default: None,
                 },
             ],
             return_type: Some(ast::Type::String),
@@ -91,6 +96,35 @@ mod tests {
         )
     }
 
+    #[test]
+    fn test_generate_js_function_with_defaults() {
+        let func_ast = ast::Function {
+            name: "transfer".to_string(),
+            parameters: vec![
+                ast::Parameter {
+                    name: "amount".to_string(),
+                    type_: ast::ParameterType::Number,
+                    required: true,
+                    default: None,
+                },
+                ast::Parameter {
+                    name: "memo".to_string(),
+                    type_: ast::ParameterType::String,
+                    required: false,
+                    default: Some(serde_json::json!("")),
+                },
+            ],
+            return_type: None,
+            statements: vec![],
+            statements_code: "".to_string(),
+        };
+
+        assert_eq!(
+            generate_js_function(&func_ast).code,
+            "function transfer (amount, memo = \"\") {\n\n}"
+        )
+    }
+
     #[test]
     fn test_generate_collection_function() {
         let collection_ast = ast::Collection {
@@ -100,6 +134,8 @@ mod tests {
                     name: "abc".to_string(),
                     type_: ast::Type::String,
                     required: true,
+                    default: None,
+                    decorators: vec![],
                 }),
                 ast::CollectionItem::Function(ast::Function {
                     name: "Hello".to_string(),
@@ -108,11 +144,13 @@ mod tests {
                             name: "a".to_string(),
                             type_: ast::ParameterType::String,
                             required: true,
+                            default: None,
                         },
                         ast::Parameter {
                             name: "b".to_string(),
                             type_: ast::ParameterType::Number,
                             required: false,
+                            default: None,
                         },
                     ],
                     return_type: Some(ast::Type::String),
@@ -126,11 +164,13 @@ mod tests {
                             name: "c".to_string(),
                             type_: ast::ParameterType::String,
                             required: true,
+                            default: None,
                         },
                         ast::Parameter {
                             name: "d".to_string(),
                             type_: ast::ParameterType::Number,
                             required: false,
+                            default: None,
                         },
                     ],
                     return_type: Some(ast::Type::String),
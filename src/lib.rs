@@ -6,9 +6,27 @@ use polylang_parser::{ast, LexicalError, ParseError};
 use serde::Serialize;
 use std::{cell::RefCell, collections::HashMap, rc::Rc};
 
-#[derive(Debug, Serialize)]
+#[derive(Debug, Default, Serialize)]
 struct Error {
     message: String,
+    /// 1-based line of the offending source, if the error has a position.
+    #[serde(skip_serializing_if = "Option::is_none")]
+    line: Option<usize>,
+    /// 0-based column within the line, in bytes.
+    #[serde(skip_serializing_if = "Option::is_none")]
+    column: Option<usize>,
+    #[serde(skip_serializing_if = "Option::is_none")]
+    length: Option<usize>,
+}
+
+/// Bumped whenever the JSON exchanged through the bindings changes shape,
+/// so hosts can invalidate anything cached from an older library.
+const ABI_VERSION: u32 = 1;
+
+#[derive(Debug, Serialize)]
+struct Version {
+    version: &'static str,
+    abi: u32,
 }
 
 fn parse_error_to_error<T>(input: &str, error: ParseError<usize, T, LexicalError>) -> Error
@@ -33,21 +51,27 @@ where
             "Error found at line {}, column {}: {}\n",
             line_number, column, message
         );
+        let length = if start_byte == end_byte {
+            1
+        } else {
+            end_byte - start_byte
+        };
 
         // deindent the line
         let line_len_before_trim = line.len();
         let line = line.trim_start();
-        let column = column - (line_len_before_trim - line.len());
+        let caret_column = column - (line_len_before_trim - line.len());
 
         message.push_str(line);
         message.push_str("\n");
-        message.push_str(&" ".repeat(column));
-        message.push_str(&"^".repeat(if start_byte == end_byte {
-            1
-        } else {
-            end_byte - start_byte
-        }));
-        Error { message }
+        message.push_str(&" ".repeat(caret_column));
+        message.push_str(&"^".repeat(length));
+        Error {
+            message,
+            line: Some(line_number),
+            column: Some(column),
+            length: Some(length),
+        }
     };
 
     match error {
@@ -105,6 +129,7 @@ fn validate_set(collection_ast_json: &str, data_json: &str) -> Result<(), Error>
         Err(err) => {
             return Err(Error {
                 message: err.to_string(),
+                ..Default::default()
             })
         }
     };
@@ -114,12 +139,14 @@ fn validate_set(collection_ast_json: &str, data_json: &str) -> Result<(), Error>
         Err(err) => {
             return Err(Error {
                 message: err.to_string(),
+                ..Default::default()
             })
         }
     };
 
     validation::validate_set(&collection_ast, &data).map_err(|e| Error {
         message: e.to_string(),
+        ..Default::default()
     })
 }
 
@@ -131,6 +158,7 @@ fn generate_collection_function(collection_ast: &str) -> Result<js::JSCollection
     let collection_ast: ast::Collection =
         serde_json::from_str(collection_ast).map_err(|e| Error {
             message: e.to_string(),
+            ..Default::default()
         })?;
 
     Ok(js::generate_js_collection(&collection_ast))
@@ -140,10 +168,55 @@ fn generate_js_collection_out_json(collection_ast: &str) -> String {
     serde_json::to_string(&generate_collection_function(collection_ast)).unwrap()
 }
 
+fn tokenize(input: &str) -> Result<Vec<polylang_parser::Token>, Error> {
+    Ok(polylang_parser::tokenize(input))
+}
+
+fn tokenize_out_json(input: &str) -> String {
+    serde_json::to_string(&tokenize(input)).unwrap()
+}
+
+fn version() -> Result<Version, Error> {
+    Ok(Version {
+        version: env!("CARGO_PKG_VERSION"),
+        abi: ABI_VERSION,
+    })
+}
+
+fn version_out_json() -> String {
+    serde_json::to_string(&version()).unwrap()
+}
+
 #[cfg(test)]
 mod tests {
     use super::*;
 
+    #[test]
+    fn test_tokenize_out_json() {
+        let output = tokenize_out_json("collection ą");
+        assert_eq!(
+            output,
+            r#"{"Ok":[{"kind":"keyword","start":0,"end":10},{"kind":"invalid","start":11,"end":13}]}"#
+        );
+    }
+
+    #[test]
+    fn test_version() {
+        let output = version_out_json();
+        assert_eq!(
+            output,
+            format!(
+                r#"{{"Ok":{{"version":"{}","abi":{}}}}}"#,
+                env!("CARGO_PKG_VERSION"),
+                ABI_VERSION
+            )
+        );
+
+        let parts = env!("CARGO_PKG_VERSION").split('.').collect::<Vec<_>>();
+        assert_eq!(parts.len(), 3);
+        assert!(parts.iter().all(|p| p.parse::<u64>().is_ok()));
+    }
+
     #[test]
     fn test_parse() {
         let input = "collection Test {}";
@@ -187,10 +260,10 @@ mod tests {
         };
 
         assert!(
-            matches!(&collection.items[0], ast::CollectionItem::Field(ast::Field { name, type_, required: true }) if name == "name" && *type_ == ast::Type::String)
+            matches!(&collection.items[0], ast::CollectionItem::Field(ast::Field { name, type_, required: true, .. }) if name == "name" && *type_ == ast::Type::String)
         );
         assert!(
-            matches!(&collection.items[1], ast::CollectionItem::Field(ast::Field { name, type_, required: true }) if name == "age" && *type_ == ast::Type::Number)
+            matches!(&collection.items[1], ast::CollectionItem::Field(ast::Field { name, type_, required: true, .. }) if name == "age" && *type_ == ast::Type::Number)
         );
     }
 
@@ -217,13 +290,170 @@ mod tests {
         };
 
         assert!(
-            matches!(&collection.items[0], ast::CollectionItem::Field(ast::Field { name, type_, required: true }) if name == "asc" && *type_ == ast::Type::String),
+            matches!(&collection.items[0], ast::CollectionItem::Field(ast::Field { name, type_, required: true, .. }) if name == "asc" && *type_ == ast::Type::String),
         );
         assert!(
-            matches!(&collection.items[1], ast::CollectionItem::Field(ast::Field { name, type_, required: true }) if name == "desc" && *type_ == ast::Type::String),
+            matches!(&collection.items[1], ast::CollectionItem::Field(ast::Field { name, type_, required: true, .. }) if name == "desc" && *type_ == ast::Type::String),
+        );
+    }
+
+    #[test]
+    fn test_collection_field_defaults() {
+        let program = parse(
+            "
+            collection Account {
+                balance: number = 0;
+                limit: number = -100;
+                currency: string = 'USD';
+                frozen: boolean = false;
+                name: string;
+            }
+            ",
+        )
+        .unwrap();
+
+        let collection = match &program.nodes[0] {
+            ast::RootNode::Collection(collection) => collection,
+            _ => panic!("Expected collection"),
+        };
+
+        let fields = collection
+            .items
+            .iter()
+            .filter_map(|item| match item {
+                ast::CollectionItem::Field(field) => Some(field),
+                _ => None,
+            })
+            .collect::<Vec<_>>();
+
+        assert!(!fields[0].required);
+        assert_eq!(fields[0].default, Some(serde_json::json!(0.0)));
+        assert_eq!(fields[1].default, Some(serde_json::json!(-100.0)));
+        assert_eq!(fields[2].default, Some(serde_json::json!("USD")));
+        assert_eq!(fields[3].default, Some(serde_json::json!(false)));
+        assert!(fields[4].required);
+        assert_eq!(fields[4].default, None);
+    }
+
+    #[test]
+    fn test_error_field_default_type() {
+        let code = "
+            collection test { count: number = 'x'; }
+        ";
+
+        let program = parse(code);
+        assert!(program.is_err());
+        eprintln!("{}", program.as_ref().unwrap_err().message);
+        assert_eq!(
+            program.unwrap_err().message,
+            r#"Error found at line 2, column 46: Default value does not match the type of field "count"
+collection test { count: number = 'x'; }
+                                  ^^^"#,
         );
     }
 
+    #[test]
+    fn test_field_regex_decorator() {
+        let program = parse(
+            "
+            collection User {
+                name: string @regex('^[a-z]+$');
+            }
+            ",
+        )
+        .unwrap();
+
+        let collection = match &program.nodes[0] {
+            ast::RootNode::Collection(collection) => collection,
+            _ => panic!("Expected collection"),
+        };
+
+        assert!(matches!(
+            &collection.items[0],
+            ast::CollectionItem::Field(ast::Field { decorators, .. })
+            if decorators == &[ast::FieldDecorator {
+                name: "regex".to_string(),
+                arguments: vec![ast::Primitive::Regex("^[a-z]+$".to_string())],
+            }]
+        ));
+    }
+
+    #[test]
+    fn test_field_range_decorators() {
+        let program = parse(
+            "
+            collection Score {
+                value: number @min(-10) @max(10);
+                label: string @minLength(1) @maxLength(32);
+            }
+            ",
+        )
+        .unwrap();
+
+        let collection = match &program.nodes[0] {
+            ast::RootNode::Collection(collection) => collection,
+            _ => panic!("Expected collection"),
+        };
+
+        let decorator = |name: &str, n: f64| ast::FieldDecorator {
+            name: name.to_string(),
+            arguments: vec![ast::Primitive::Number(n)],
+        };
+
+        assert!(matches!(
+            &collection.items[0],
+            ast::CollectionItem::Field(ast::Field { decorators, .. })
+            if decorators == &[decorator("min", -10.0), decorator("max", 10.0)]
+        ));
+        assert!(matches!(
+            &collection.items[1],
+            ast::CollectionItem::Field(ast::Field { decorators, .. })
+            if decorators == &[decorator("minLength", 1.0), decorator("maxLength", 32.0)]
+        ));
+    }
+
+    #[test]
+    fn test_error_field_decorators() {
+        let cases = [
+            (
+                "collection test { name: string @regex('[a-'); }",
+                r#"Error found at line 2, column 43: Invalid regex "[a-": unclosed character class
+collection test { name: string @regex('[a-'); }
+                               ^^^^^^^^^^^^^"#,
+            ),
+            (
+                "collection test { name: string @regex('(a+)+'); }",
+                r#"Error found at line 2, column 43: Regex "(a+)+" nests unbounded quantifiers, which can backtrack catastrophically
+collection test { name: string @regex('(a+)+'); }
+                               ^^^^^^^^^^^^^^^"#,
+            ),
+            (
+                "collection test { age: number @regex('a'); }",
+                r#"Error found at line 2, column 42: @regex only applies to string fields
+collection test { age: number @regex('a'); }
+                              ^^^^^^^^^^^"#,
+            ),
+            (
+                "collection test { name: string @min(0); }",
+                r#"Error found at line 2, column 43: @min only applies to number fields
+collection test { name: string @min(0); }
+                               ^^^^^^^"#,
+            ),
+            (
+                "collection test { name: string @maxLength(1.5); }",
+                r#"Error found at line 2, column 43: @maxLength takes a non-negative integer
+collection test { name: string @maxLength(1.5); }
+                               ^^^^^^^^^^^^^^^"#,
+            ),
+        ];
+
+        for (code, expected) in cases {
+            let program = parse(&format!("\n            {}\n        ", code));
+            assert!(program.is_err());
+            assert_eq!(program.unwrap_err().message, expected);
+        }
+    }
+
     #[test]
     fn test_collection_with_functions() {
         let program = parse(
@@ -260,10 +490,66 @@ mod tests {
             matches!(function.statements[0], ast::Statement::Return(ast::Expression::Primitive(ast::Primitive::Number(number))) if number == 42.0)
         );
         assert!(
-            matches!(&function.parameters[0], ast::Parameter{ name, type_, required } if *required == true && name == "a" && *type_ == ast::ParameterType::Number)
+            matches!(&function.parameters[0], ast::Parameter{ name, type_, required, default: None } if *required == true && name == "a" && *type_ == ast::ParameterType::Number)
         );
         assert!(
-            matches!(&function.parameters[1], ast::Parameter{ name, type_, required } if *required == false && name == "b" && *type_ == ast::ParameterType::String)
+            matches!(&function.parameters[1], ast::Parameter{ name, type_, required, default: None } if *required == false && name == "b" && *type_ == ast::ParameterType::String)
+        );
+    }
+
+    #[test]
+    fn test_function_parameter_defaults() {
+        let program = parse(
+            "
+            function transfer(amount: number, memo: string = '', fee: number = -1, notify: boolean = true) {}
+            ",
+        )
+        .unwrap();
+
+        let function = match &program.nodes[0] {
+            ast::RootNode::Function(function) => function,
+            _ => panic!("Expected function"),
+        };
+
+        assert!(function.parameters[0].required);
+        assert_eq!(function.parameters[0].default, None);
+        assert!(!function.parameters[1].required);
+        assert_eq!(function.parameters[1].default, Some(serde_json::json!("")));
+        assert_eq!(function.parameters[2].default, Some(serde_json::json!(-1.0)));
+        assert_eq!(function.parameters[3].default, Some(serde_json::json!(true)));
+    }
+
+    #[test]
+    fn test_error_parameter_default_type() {
+        let code = "
+            function f(a: number = 'x') {}
+        ";
+
+        let program = parse(code);
+        assert!(program.is_err());
+        eprintln!("{}", program.as_ref().unwrap_err().message);
+        assert_eq!(
+            program.unwrap_err().message,
+            r#"Error found at line 2, column 35: Default value does not match the type of parameter "a"
+function f(a: number = 'x') {}
+                       ^^^"#,
+        );
+    }
+
+    #[test]
+    fn test_error_required_parameter_after_optional() {
+        let code = "
+            function f(a: number = 1, b: string) {}
+        ";
+
+        let program = parse(code);
+        assert!(program.is_err());
+        eprintln!("{}", program.as_ref().unwrap_err().message);
+        assert_eq!(
+            program.unwrap_err().message,
+            r#"Error found at line 2, column 38: Required parameter "b" cannot follow an optional parameter
+function f(a: number = 1, b: string) {}
+                          ^"#,
         );
     }
 
@@ -278,6 +564,51 @@ mod tests {
         );
     }
 
+    #[test]
+    fn test_hex_binary_number() {
+        let expr = polylang_parser::parse_expression("0xFF & 0b1010").unwrap();
+
+        assert!(matches!(
+            expr,
+            ast::Expression::BitAnd(left, right) if *left == ast::Expression::Primitive(ast::Primitive::Number(255.0))
+                && *right == ast::Expression::Primitive(ast::Primitive::Number(10.0)),
+        ));
+    }
+
+    #[test]
+    fn test_error_hex_number_overflow() {
+        let expr = polylang_parser::parse_expression("0xFFFFFFFFFFFFFFFFFF");
+
+        assert!(expr.is_err());
+    }
+
+    #[test]
+    fn test_exponent() {
+        let expr = polylang_parser::parse_expression("x ** 0").unwrap();
+
+        assert!(matches!(
+            expr,
+            ast::Expression::Exponent(left, right) if *left == ast::Expression::Ident("x".to_owned())
+                && *right == ast::Expression::Primitive(ast::Primitive::Number(0.0)),
+        ));
+    }
+
+    #[test]
+    fn test_exponent_right_associative() {
+        let expr = polylang_parser::parse_expression("2 ** 3 ** 2").unwrap();
+
+        let (left, right) = match expr {
+            ast::Expression::Exponent(left, right) => (left, right),
+            _ => panic!("Expected exponent"),
+        };
+        assert_eq!(*left, ast::Expression::Primitive(ast::Primitive::Number(2.0)));
+        assert!(matches!(
+            *right,
+            ast::Expression::Exponent(left, right) if *left == ast::Expression::Primitive(ast::Primitive::Number(3.0))
+                && *right == ast::Expression::Primitive(ast::Primitive::Number(2.0)),
+        ));
+    }
+
     #[test]
     fn test_string() {
         let string = polylang_parser::parse_expression("'hello world'");
@@ -336,6 +667,159 @@ mod tests {
         assert_eq!(if_.else_statements.len(), 1);
     }
 
+    #[test]
+    fn test_continue() {
+        let program = parse(
+            "
+            function x() {
+                while (true) {
+                    continue;
+                }
+            }
+            ",
+        );
+
+        let mut program = program.unwrap();
+        let mut function = match program.nodes.pop().unwrap() {
+            ast::RootNode::Function(function) => function,
+            _ => panic!("Expected function"),
+        };
+
+        let while_ = match function.statements.pop().unwrap() {
+            ast::Statement::While(while_) => while_,
+            _ => panic!("Expected while"),
+        };
+
+        assert!(matches!(
+            while_.statements.as_slice(),
+            [ast::Statement::Continue]
+        ));
+    }
+
+    #[test]
+    fn test_labeled_break() {
+        let program = parse(
+            "
+            function x() {
+                outer: for (let i = 0; i < 3; i += 1) {
+                    for (let j = 0; j < 3; j += 1) {
+                        if (j == 1) {
+                            break outer;
+                        }
+                    }
+                }
+            }
+            ",
+        );
+
+        let mut program = program.unwrap();
+        let mut function = match program.nodes.pop().unwrap() {
+            ast::RootNode::Function(function) => function,
+            _ => panic!("Expected function"),
+        };
+
+        let mut outer = match function.statements.pop().unwrap() {
+            ast::Statement::For(for_) => for_,
+            _ => panic!("Expected for"),
+        };
+        assert_eq!(outer.label, Some("outer".to_string()));
+
+        let inner = match outer.statements.pop().unwrap() {
+            ast::Statement::For(for_) => for_,
+            _ => panic!("Expected for"),
+        };
+        assert_eq!(inner.label, None);
+
+        assert!(matches!(
+            inner.statements.as_slice(),
+            [ast::Statement::If(ast::If { then_statements, .. })]
+                if matches!(then_statements.as_slice(), [ast::Statement::LabeledBreak(label)] if label == "outer")
+        ));
+    }
+
+    #[test]
+    fn test_labeled_continue() {
+        let program = parse(
+            "
+            function x() {
+                rows: while (true) {
+                    while (true) {
+                        continue rows;
+                    }
+                }
+            }
+            ",
+        );
+
+        let mut program = program.unwrap();
+        let mut function = match program.nodes.pop().unwrap() {
+            ast::RootNode::Function(function) => function,
+            _ => panic!("Expected function"),
+        };
+
+        let mut outer = match function.statements.pop().unwrap() {
+            ast::Statement::While(while_) => while_,
+            _ => panic!("Expected while"),
+        };
+        assert_eq!(outer.label, Some("rows".to_string()));
+
+        let inner = match outer.statements.pop().unwrap() {
+            ast::Statement::While(while_) => while_,
+            _ => panic!("Expected while"),
+        };
+
+        assert!(matches!(
+            inner.statements.as_slice(),
+            [ast::Statement::LabeledContinue(label)] if label == "rows"
+        ));
+    }
+
+    #[test]
+    fn test_error_undefined_label() {
+        let code = "
+            function f() {
+                while (true) {
+                    break outer;
+                }
+            }
+        ";
+
+        let program = parse(code);
+        assert!(program.is_err());
+        eprintln!("{}", program.as_ref().unwrap_err().message);
+        assert_eq!(
+            program.unwrap_err().message,
+            r#"Error found at line 2, column 21: Undefined label "outer"
+function f() {
+         ^"#,
+        );
+    }
+
+    #[test]
+    fn test_error_duplicate_label() {
+        let code = "
+            collection Account {
+                f() {
+                    loop: while (true) {
+                        loop: while (true) {
+                            break loop;
+                        }
+                    }
+                }
+            }
+        ";
+
+        let program = parse(code);
+        assert!(program.is_err());
+        eprintln!("{}", program.as_ref().unwrap_err().message);
+        assert_eq!(
+            program.unwrap_err().message,
+            r#"Error found at line 3, column 16: Duplicate label "loop"
+f() {
+^"#,
+        );
+    }
+
     #[test]
     fn test_call() {
         let call = polylang_parser::parse_expression("get_age(a, b, c)");
@@ -400,25 +884,25 @@ mod tests {
 
         assert!(matches!(
             &collection.items[0],
-            ast::CollectionItem::Field(ast::Field { name, type_, required: true })
+            ast::CollectionItem::Field(ast::Field { name, type_, required: true, .. })
             if name == "name" && *type_ == ast::Type::String
         ));
 
         assert!(matches!(
             &collection.items[1],
-            ast::CollectionItem::Field(ast::Field { name, type_, required: false })
+            ast::CollectionItem::Field(ast::Field { name, type_, required: false, .. })
             if name == "age" && *type_ == ast::Type::Number
         ));
 
         assert!(matches!(
             &collection.items[2],
-            ast::CollectionItem::Field(ast::Field { name, type_, required: true })
+            ast::CollectionItem::Field(ast::Field { name, type_, required: true, .. })
             if name == "balance" && *type_ == ast::Type::Number
         ));
 
         assert!(matches!(
             &collection.items[3],
-            ast::CollectionItem::Field(ast::Field { name, type_, required: true })
+            ast::CollectionItem::Field(ast::Field { name, type_, required: true, .. })
             if name == "publicKey" && *type_ == ast::Type::String
         ));
 
@@ -530,6 +1014,21 @@ collection test-cities {}
         );
     }
 
+    #[test]
+    fn test_error_position() {
+        let code = "
+            collection X { name string }
+        ";
+
+        let err = parse(code).unwrap_err();
+        assert_eq!(err.line, Some(2));
+        assert_eq!(err.column, Some(32));
+        assert_eq!(err.length, Some(6));
+
+        let output = parse_out_json(code);
+        assert!(output.contains(r#""line":2,"column":32,"length":6"#));
+    }
+
     #[test]
     fn test_error_invalid_token() {
         let code = "
@@ -583,6 +1082,99 @@ name: object;
         );
     }
 
+    #[test]
+    fn test_error_duplicate_field() {
+        let code = "
+            collection test {
+                name: string;
+                name: number;
+            }
+        ";
+
+        let collection = parse(code);
+        assert!(collection.is_err());
+        eprintln!("{}", collection.as_ref().unwrap_err().message);
+        assert_eq!(
+            collection.unwrap_err().message,
+            r#"Error found at line 4, column 16: Duplicate field "name"
+name: number;
+^"#,
+        );
+    }
+
+    #[test]
+    fn test_error_reserved_field() {
+        let code = "
+            collection test {
+                this: string;
+            }
+        ";
+
+        let collection = parse(code);
+        assert!(collection.is_err());
+        assert_eq!(
+            collection.unwrap_err().message,
+            r#"Error found at line 3, column 16: "this" is a reserved identifier
+this: string;
+^^^^"#,
+        );
+    }
+
+    #[test]
+    fn test_error_reserved_parameter() {
+        let code = "
+            collection test {
+                function f(ctx: string) {}
+            }
+        ";
+
+        let collection = parse(code);
+        assert!(collection.is_err());
+        assert_eq!(
+            collection.unwrap_err().message,
+            r#"Error found at line 3, column 27: "ctx" is a reserved identifier
+function f(ctx: string) {}
+           ^^^"#,
+        );
+    }
+
+    #[test]
+    fn test_error_duplicate_function() {
+        let code = "
+            collection test {
+                function f() {}
+                function f() {}
+            }
+        ";
+
+        let collection = parse(code);
+        assert!(collection.is_err());
+        eprintln!("{}", collection.as_ref().unwrap_err().message);
+        assert_eq!(
+            collection.unwrap_err().message,
+            r#"Error found at line 4, column 16: Duplicate function "f"
+function f() {}
+^"#,
+        );
+    }
+
+    #[test]
+    fn test_error_duplicate_parameter() {
+        let code = "
+            function f(a: string, a: number) {}
+        ";
+
+        let program = parse(code);
+        assert!(program.is_err());
+        eprintln!("{}", program.as_ref().unwrap_err().message);
+        assert_eq!(
+            program.unwrap_err().message,
+            r#"Error found at line 2, column 34: Duplicate parameter "a"
+function f(a: string, a: number) {}
+                      ^"#,
+        );
+    }
+
     #[test]
     fn test_array_map_field() {
         let cases = [
@@ -592,6 +1184,8 @@ name: object;
                     name: "numbers".to_string(),
                     type_: ast::Type::Array(Box::new(ast::Type::Number)),
                     required: true,
+                    default: None,
+                    decorators: vec![],
                 }],
             ),
             (
@@ -600,6 +1194,8 @@ name: object;
                     name: "strings".to_string(),
                     type_: ast::Type::Array(Box::new(ast::Type::String)),
                     required: true,
+                    default: None,
+                    decorators: vec![],
                 }],
             ),
             (
@@ -608,6 +1204,8 @@ name: object;
                     name: "numToStr".to_string(),
                     type_: ast::Type::Map(Box::new(ast::Type::Number), Box::new(ast::Type::String)),
                     required: true,
+                    default: None,
+                    decorators: vec![],
                 }],
             ),
             (
@@ -616,6 +1214,8 @@ name: object;
                     name: "strToNum".to_string(),
                     type_: ast::Type::Map(Box::new(ast::Type::String), Box::new(ast::Type::Number)),
                     required: true,
+                    default: None,
+                    decorators: vec![],
                 }],
             ),
         ];
@@ -637,6 +1237,7 @@ name: object;
                             name,
                             type_,
                             required,
+                            ..
                         }) if name == &item.name && type_ == &item.type_ && required == &item.required
                     ),
                     "expected: {:?}, got: {:?}",
@@ -659,14 +1260,20 @@ name: object;
                             name: "name".to_string(),
                             type_: ast::Type::String,
                             required: true,
+                            default: None,
+                            decorators: vec![],
                         },
                         ast::Field {
                             name: "age".to_string(),
                             type_: ast::Type::Number,
                             required: true,
+                            default: None,
+                            decorators: vec![],
                         },
                     ]),
                     required: true,
+                    default: None,
+                    decorators: vec![],
                 }],
             ),
             (
@@ -677,8 +1284,12 @@ name: object;
                         name: "name".to_string(),
                         type_: ast::Type::String,
                         required: false,
+                        default: None,
+                        decorators: vec![],
                     }]),
                     required: true,
+                    default: None,
+                    decorators: vec![],
                 }],
             ),
             (
@@ -691,10 +1302,16 @@ name: object;
                             name: "name".to_string(),
                             type_: ast::Type::String,
                             required: true,
+                            default: None,
+                            decorators: vec![],
                         }]),
                         required: true,
+                        default: None,
+                        decorators: vec![],
                     }]),
                     required: true,
+                    default: None,
+                    decorators: vec![],
                 }],
             ),
         ];
@@ -716,6 +1333,7 @@ name: object;
                             name,
                             type_,
                             required,
+                            ..
                         }) if name == &item.name && type_ == &item.type_ && required == &item.required
                     ),
                     "expected: {:?}, got: {:?}",
@@ -58,6 +58,10 @@ pub enum ValidationError<'a> {
     ExtraField {
         path: PathParts<'a>,
     },
+    ConstraintViolation {
+        path: PathParts<'a>,
+        message: String,
+    },
 }
 
 impl std::fmt::Display for ValidationError<'_> {
@@ -76,6 +80,9 @@ impl std::fmt::Display for ValidationError<'_> {
             ValidationError::ExtraField { path } => {
                 write!(f, "Extra field at path {}", path)
             }
+            ValidationError::ConstraintViolation { path, message } => {
+                write!(f, "Constraint violated at path {}, {}", path, message)
+            }
         }
     }
 }
@@ -149,7 +156,7 @@ pub(crate) fn validate_value<'a>(
                 for (key, value) in map {
                     path.0.push(PathPart::Field(key));
                     match kt.deref() {
-                        ast::Type::String => return Ok(()),
+                        ast::Type::String => {}
                         ast::Type::Number => {
                             if key.parse::<f64>().is_err() {
                                 return Err(ValidationError::InvalidType {
@@ -192,6 +199,7 @@ pub(crate) fn validate_value<'a>(
                     path.0.push(PathPart::Field(key));
                     if let Some(field) = obj.iter().find(|f| &f.name == key) {
                         validate_value(path, value, &field.type_)?;
+                        validate_decorators(path, value, field)?;
                     } else {
                         return Err(ValidationError::ExtraField { path: path.clone() });
                     }
@@ -209,6 +217,75 @@ pub(crate) fn validate_value<'a>(
     }
 }
 
+/// Checks value, which validate_value has already checked against the
+/// field's type, against the constraints of the field's decorators.
+fn validate_decorators<'a>(
+    path: &PathParts<'a>,
+    value: &Value,
+    field: &'a ast::Field,
+) -> Result<(), ValidationError<'a>> {
+    for decorator in &field.decorators {
+        match (
+            decorator.name.as_str(),
+            decorator.arguments.as_slice(),
+            value,
+        ) {
+            ("regex", [ast::Primitive::Regex(pattern)], Value::String(s)) => {
+                // The parser rejects invalid patterns, so one that fails to
+                // compile came from elsewhere and matches nothing
+                let matches = regex::Regex::new(pattern)
+                    .map(|re| re.is_match(s))
+                    .unwrap_or(false);
+                if !matches {
+                    return Err(ValidationError::ConstraintViolation {
+                        path: path.clone(),
+                        message: format!("value does not match @regex('{}')", pattern),
+                    });
+                }
+            }
+            ("min", [ast::Primitive::Number(min)], Value::Number(n)) if n < min => {
+                return Err(ValidationError::ConstraintViolation {
+                    path: path.clone(),
+                    message: format!("value {} is less than @min({})", n, min),
+                });
+            }
+            ("max", [ast::Primitive::Number(max)], Value::Number(n)) if n > max => {
+                return Err(ValidationError::ConstraintViolation {
+                    path: path.clone(),
+                    message: format!("value {} is greater than @max({})", n, max),
+                });
+            }
+            ("minLength", [ast::Primitive::Number(min)], Value::String(s))
+                if (s.chars().count() as f64) < *min =>
+            {
+                return Err(ValidationError::ConstraintViolation {
+                    path: path.clone(),
+                    message: format!(
+                        "length {} is less than @minLength({})",
+                        s.chars().count(),
+                        min
+                    ),
+                });
+            }
+            ("maxLength", [ast::Primitive::Number(max)], Value::String(s))
+                if (s.chars().count() as f64) > *max =>
+            {
+                return Err(ValidationError::ConstraintViolation {
+                    path: path.clone(),
+                    message: format!(
+                        "length {} is greater than @maxLength({})",
+                        s.chars().count(),
+                        max
+                    ),
+                });
+            }
+            _ => {}
+        }
+    }
+
+    Ok(())
+}
+
 pub(crate) fn validate_set<'a>(
     collection: &'a ast::Collection,
     data: &'a HashMap<String, Value>,
@@ -234,11 +311,9 @@ pub(crate) fn validate_set<'a>(
         }
 
         if let Some(value) = data.get(&field.name) {
-            validate_value(
-                &mut PathParts(vec![PathPart::Field(&field.name)]),
-                value,
-                &field.type_,
-            )?;
+            let mut path = PathParts(vec![PathPart::Field(&field.name)]);
+            validate_value(&mut path, value, &field.type_)?;
+            validate_decorators(&path, value, field)?;
         }
     }
 
@@ -266,11 +341,15 @@ mod tests {
                     name: "name".to_string(),
                     type_: ast::Type::String,
                     required: true,
+                    default: None,
+                    decorators: vec![],
                 }),
                 ast::CollectionItem::Field(ast::Field {
                     name: "age".to_string(),
                     type_: ast::Type::Number,
                     required: false,
+                    default: None,
+                    decorators: vec![],
                 }),
             ],
         };
@@ -291,6 +370,8 @@ mod tests {
                 name: "tags".to_string(),
                 type_: ast::Type::Array(Box::new(ast::Type::String)),
                 required: false,
+                default: None,
+                decorators: vec![],
             })],
         };
 
@@ -313,6 +394,8 @@ mod tests {
                 name: "tags".to_string(),
                 type_: ast::Type::Array(Box::new(ast::Type::String)),
                 required: false,
+                default: None,
+                decorators: vec![],
             })],
         };
 
@@ -341,6 +424,8 @@ mod tests {
                 name: "tags".to_string(),
                 type_: ast::Type::Map(Box::new(ast::Type::String), Box::new(ast::Type::Number)),
                 required: false,
+                default: None,
+                decorators: vec![],
             })],
         };
 
@@ -369,6 +454,8 @@ mod tests {
                     )),
                 ),
                 required: false,
+                default: None,
+                decorators: vec![],
             })],
         };
 
@@ -403,6 +490,8 @@ mod tests {
                 name: "tags".to_string(),
                 type_: ast::Type::Map(Box::new(ast::Type::Number), Box::new(ast::Type::Number)),
                 required: false,
+                default: None,
+                decorators: vec![],
             })],
         };
 
@@ -425,6 +514,8 @@ mod tests {
                 name: "tags".to_string(),
                 type_: ast::Type::Map(Box::new(ast::Type::Number), Box::new(ast::Type::Number)),
                 required: false,
+                default: None,
+                decorators: vec![],
             })],
         };
 
@@ -456,6 +547,8 @@ mod tests {
                 name: "tags".to_string(),
                 type_: ast::Type::Map(Box::new(ast::Type::Number), Box::new(ast::Type::Number)),
                 required: false,
+                default: None,
+                decorators: vec![],
             })],
         };
 
@@ -479,6 +572,97 @@ mod tests {
         );
     }
 
+    #[test]
+    fn test_validate_nested_map_array() {
+        let collection = ast::Collection {
+            name: "users".to_string(),
+            items: vec![ast::CollectionItem::Field(ast::Field {
+                name: "scores".to_string(),
+                type_: ast::Type::Map(
+                    Box::new(ast::Type::String),
+                    Box::new(ast::Type::Array(Box::new(ast::Type::Array(Box::new(
+                        ast::Type::Number,
+                    ))))),
+                ),
+                required: true,
+                default: None,
+                decorators: vec![],
+            })],
+        };
+
+        let data = HashMap::from([(
+            "scores".to_string(),
+            Value::Map(HashMap::from([
+                (
+                    "a".to_string(),
+                    Value::Array(vec![
+                        Value::Array(vec![Value::Number(1.0), Value::Number(2.0)]),
+                        Value::Array(vec![Value::Number(3.0)]),
+                    ]),
+                ),
+                ("b".to_string(), Value::Array(vec![Value::Array(vec![])])),
+            ])),
+        )]);
+
+        assert!(validate_set(&collection, &data).is_ok());
+    }
+
+    #[test]
+    fn test_validate_nested_map_array_invalid_leaf() {
+        let collection = ast::Collection {
+            name: "users".to_string(),
+            items: vec![ast::CollectionItem::Field(ast::Field {
+                name: "scores".to_string(),
+                type_: ast::Type::Map(
+                    Box::new(ast::Type::String),
+                    Box::new(ast::Type::Array(Box::new(ast::Type::Array(Box::new(
+                        ast::Type::Object(vec![ast::Field {
+                            name: "n".to_string(),
+                            type_: ast::Type::Number,
+                            required: true,
+                            default: None,
+                            decorators: vec![],
+                        }]),
+                    ))))),
+                ),
+                required: true,
+                default: None,
+                decorators: vec![],
+            })],
+        };
+
+        let data = HashMap::from([(
+            "scores".to_string(),
+            Value::Map(HashMap::from([(
+                "a".to_string(),
+                Value::Array(vec![
+                    Value::Array(vec![Value::Map(HashMap::from([(
+                        "n".to_string(),
+                        Value::Number(1.0),
+                    )]))]),
+                    Value::Array(vec![Value::Map(HashMap::from([(
+                        "n".to_string(),
+                        Value::String("x".to_string()),
+                    )]))]),
+                ]),
+            )])),
+        )]);
+
+        assert_eq!(
+            validate_set(&collection, &data).unwrap_err(),
+            ValidationError::InvalidType {
+                path: PathParts(vec![
+                    PathPart::Field("scores"),
+                    PathPart::Field("a"),
+                    PathPart::Index(1),
+                    PathPart::Index(0),
+                    PathPart::Field("n"),
+                ]),
+                expected: ast::Type::Number,
+            }
+        );
+    }
+
     #[test]
     fn test_validate_object() {
         let cases = [
@@ -491,8 +675,12 @@ mod tests {
                             name: "name".to_string(),
                             type_: ast::Type::String,
                             required: true,
+                            default: None,
+                            decorators: vec![],
                         }]),
                         required: true,
+                        default: None,
+                        decorators: vec![],
                     })],
                 },
                 HashMap::from([(
@@ -512,8 +700,12 @@ mod tests {
                             name: "name".to_string(),
                             type_: ast::Type::String,
                             required: false,
+                            default: None,
+                            decorators: vec![],
                         }]),
                         required: true,
+                        default: None,
+                        decorators: vec![],
                     })],
                 },
                 HashMap::from([("info".to_string(), Value::Map(HashMap::from([])))]),
@@ -527,8 +719,12 @@ mod tests {
                             name: "name".to_string(),
                             type_: ast::Type::String,
                             required: true,
+                            default: None,
+                            decorators: vec![],
                         }]),
                         required: false,
+                        default: None,
+                        decorators: vec![],
                     })],
                 },
                 HashMap::from([]),
@@ -554,8 +750,12 @@ mod tests {
                     name: "name".to_string(),
                     type_: ast::Type::String,
                     required: true,
+                    default: None,
+                    decorators: vec![],
                 }]),
                 required: true,
+                default: None,
+                decorators: vec![],
             })],
         };
 
@@ -582,8 +782,12 @@ mod tests {
                     name: "name".to_string(),
                     type_: ast::Type::String,
                     required: true,
+                    default: None,
+                    decorators: vec![],
                 }]),
                 required: true,
+                default: None,
+                decorators: vec![],
             })],
         };
 
@@ -616,11 +820,15 @@ mod tests {
                     name: "name".to_string(),
                     type_: ast::Type::String,
                     required: true,
+                    default: None,
+                    decorators: vec![],
                 }),
                 ast::CollectionItem::Field(ast::Field {
                     name: "age".to_string(),
                     type_: ast::Type::Number,
                     required: false,
+                    default: None,
+                    decorators: vec![],
                 }),
             ],
         };
@@ -648,11 +856,15 @@ mod tests {
                     name: "name".to_string(),
                     type_: ast::Type::String,
                     required: true,
+                    default: None,
+                    decorators: vec![],
                 }),
                 ast::CollectionItem::Field(ast::Field {
                     name: "age".to_string(),
                     type_: ast::Type::Number,
                     required: false,
+                    default: None,
+                    decorators: vec![],
                 }),
             ],
         };
@@ -684,11 +896,15 @@ mod tests {
                     name: "name".to_string(),
                     type_: ast::Type::String,
                     required: true,
+                    default: None,
+                    decorators: vec![],
                 }),
                 ast::CollectionItem::Field(ast::Field {
                     name: "age".to_string(),
                     type_: ast::Type::Number,
                     required: false,
+                    default: None,
+                    decorators: vec![],
                 }),
             ],
         };
@@ -719,6 +935,8 @@ mod tests {
                 name: "is_admin".to_string(),
                 type_: ast::Type::Boolean,
                 required: true,
+                default: None,
+                decorators: vec![],
             })],
         };
 
@@ -745,4 +963,132 @@ mod tests {
             })
         );
     }
+
+    #[test]
+    fn test_validate_regex() {
+        let collection = ast::Collection {
+            name: "users".to_string(),
+            items: vec![ast::CollectionItem::Field(ast::Field {
+                name: "name".to_string(),
+                type_: ast::Type::String,
+                required: true,
+                default: None,
+                decorators: vec![ast::FieldDecorator {
+                    name: "regex".to_string(),
+                    arguments: vec![ast::Primitive::Regex("^[a-z]+$".to_string())],
+                }],
+            })],
+        };
+
+        assert!(validate_set(
+            &collection,
+            &HashMap::from([("name".to_string(), Value::String("john".to_string()))])
+        )
+        .is_ok());
+
+        assert_eq!(
+            validate_set(
+                &collection,
+                &HashMap::from([("name".to_string(), Value::String("John1".to_string()))])
+            ),
+            Err(ValidationError::ConstraintViolation {
+                path: PathParts(vec![PathPart::Field("name")]),
+                message: "value does not match @regex('^[a-z]+$')".to_string(),
+            })
+        );
+    }
+
+    #[test]
+    fn test_validate_min_max() {
+        let collection = ast::Collection {
+            name: "scores".to_string(),
+            items: vec![ast::CollectionItem::Field(ast::Field {
+                name: "score".to_string(),
+                type_: ast::Type::Number,
+                required: true,
+                default: None,
+                decorators: vec![
+                    ast::FieldDecorator {
+                        name: "min".to_string(),
+                        arguments: vec![ast::Primitive::Number(0.0)],
+                    },
+                    ast::FieldDecorator {
+                        name: "max".to_string(),
+                        arguments: vec![ast::Primitive::Number(100.0)],
+                    },
+                ],
+            })],
+        };
+
+        let validate = |n: f64| {
+            validate_set(
+                &collection,
+                &HashMap::from([("score".to_string(), Value::Number(n))]),
+            )
+            .map_err(|e| e.to_string())
+        };
+
+        assert_eq!(validate(0.0), Ok(()));
+        assert_eq!(validate(100.0), Ok(()));
+        assert_eq!(
+            validate(-0.5),
+            Err("Constraint violated at path score, value -0.5 is less than @min(0)".to_string())
+        );
+        assert_eq!(
+            validate(101.0),
+            Err(
+                "Constraint violated at path score, value 101 is greater than @max(100)"
+                    .to_string()
+            )
+        );
+    }
+
+    #[test]
+    fn test_validate_min_max_length() {
+        let collection = ast::Collection {
+            name: "users".to_string(),
+            items: vec![ast::CollectionItem::Field(ast::Field {
+                name: "name".to_string(),
+                type_: ast::Type::String,
+                required: true,
+                default: None,
+                decorators: vec![
+                    ast::FieldDecorator {
+                        name: "minLength".to_string(),
+                        arguments: vec![ast::Primitive::Number(2.0)],
+                    },
+                    ast::FieldDecorator {
+                        name: "maxLength".to_string(),
+                        arguments: vec![ast::Primitive::Number(4.0)],
+                    },
+                ],
+            })],
+        };
+
+        let validate = |s: &str| {
+            validate_set(
+                &collection,
+                &HashMap::from([("name".to_string(), Value::String(s.to_string()))]),
+            )
+            .map_err(|e| e.to_string())
+        };
+
+        assert_eq!(validate("ab"), Ok(()));
+        assert_eq!(validate("abcd"), Ok(()));
+        // Lengths count characters, not bytes
+        assert_eq!(validate("ééé"), Ok(()));
+        assert_eq!(
+            validate("a"),
+            Err(
+                "Constraint violated at path name, length 1 is less than @minLength(2)".to_string()
+            )
+        );
+        assert_eq!(
+            validate("abcde"),
+            Err(
+                "Constraint violated at path name, length 5 is greater than @maxLength(4)"
+                    .to_string()
+            )
+        );
+    }
 }